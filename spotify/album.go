@@ -0,0 +1,145 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// SimpleAlbum is the simplified album object, as it is included e.g. in tracks.
+type SimpleAlbum struct {
+	AlbumType            string         `json:"album_type"`
+	Artists              []SimpleArtist `json:"artists"`
+	AvailableMarkets     []string       `json:"available_markets"`
+	ExternalURLs         ExternalURLs   `json:"external_urls"`
+	Href                 string         `json:"href"`
+	ID                   string         `json:"id"`
+	Images               []Image        `json:"images"`
+	Name                 string         `json:"name"`
+	ReleaseDate          string         `json:"release_date"`
+	ReleaseDatePrecision string         `json:"release_date_precision"`
+	TotalTracks          int            `json:"total_tracks"`
+	Type                 string         `json:"type"`
+	URI                  string         `json:"uri"`
+}
+
+// SimpleAlbumPage is a page of simplified albums, e.g. of a search.
+type SimpleAlbumPage struct {
+	Href  string        `json:"href"`
+	Items []SimpleAlbum `json:"items"`
+	Pagination
+}
+
+// Album is the full album object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-album
+type Album struct {
+	SimpleAlbum
+	Copyrights  []Copyright     `json:"copyrights"`
+	ExternalIDs ExternalIDs     `json:"external_ids"`
+	Genres      []string        `json:"genres"`
+	Label       string          `json:"label"`
+	Popularity  int             `json:"popularity"`
+	Tracks      SimpleTrackPage `json:"tracks"`
+}
+
+// maxAlbumsPerRequest is the maximum number of ids of a single request to the several albums endpoint.
+const maxAlbumsPerRequest = 20
+
+// GetAlbum returns the album with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-album
+// The market is optional and defaults to the market of the client, see SetMarket.
+func (c *Client) GetAlbum(ctx context.Context, id spotifyuri.ID, market string) (Album, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Album{}, err
+	}
+
+	err = validateID(spotifyuri.KindAlbum, id)
+	if err != nil {
+		return Album{}, err
+	}
+
+	var a Album
+	err = c.get(ctx, endpoint("albums", string(id))+marketQuery(market), &a)
+	if err != nil {
+		return Album{}, err
+	}
+
+	return a, nil
+}
+
+// GetAlbums returns the albums with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-multiple-albums
+// The albums are in the same order as the ids, unknown ids result in a nil album.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetAlbums(ctx context.Context, ids []spotifyuri.ID, market string) ([]*Album, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Album, len(ids))
+	err = batch(ctx, spotifyuri.KindAlbum, ids, maxAlbumsPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Albums []*Album `json:"albums"`
+		}
+		err := c.get(ctx, endpoint("albums")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Albums)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func albumTracksURL(id spotifyuri.ID, market string, opts PageOptions) (string, error) {
+	err := validateID(spotifyuri.KindAlbum, id)
+	if err != nil {
+		return "", err
+	}
+
+	return pagedURL(endpoint("albums", string(id), "tracks"), market, opts)
+}
+
+// GetAlbumTracks returns a page of the tracks of an album as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-albums-tracks
+func (c *Client) GetAlbumTracks(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) (SimpleTrackPage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return SimpleTrackPage{}, err
+	}
+
+	u, err := albumTracksURL(id, market, opts)
+	if err != nil {
+		return SimpleTrackPage{}, err
+	}
+
+	var page SimpleTrackPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SimpleTrackPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateAlbumTracks returns a paginator over all tracks of an album, the pages are of type SimpleTrackPage.
+func (c *Client) PaginateAlbumTracks(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := albumTracksURL(id, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
@@ -0,0 +1,236 @@
+package spotify
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// SimpleArtist is the simplified artist object, as it is included e.g. in tracks and albums.
+type SimpleArtist struct {
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Href         string       `json:"href"`
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
+
+// Artist is the full artist object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-artist
+type Artist struct {
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Followers    Followers    `json:"followers"`
+	Genres       []string     `json:"genres"`
+	Href         string       `json:"href"`
+	ID           string       `json:"id"`
+	Images       []Image      `json:"images"`
+	Name         string       `json:"name"`
+	Popularity   int          `json:"popularity"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
+
+// ArtistPage is a page of artists, e.g. of a search.
+type ArtistPage struct {
+	Href  string   `json:"href"`
+	Items []Artist `json:"items"`
+	Pagination
+}
+
+// maxArtistsPerRequest is the maximum number of ids of a single request to the several artists endpoint.
+const maxArtistsPerRequest = 50
+
+// GetArtist returns the artist with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-artist
+func (c *Client) GetArtist(ctx context.Context, id spotifyuri.ID) (Artist, error) {
+	err := validateID(spotifyuri.KindArtist, id)
+	if err != nil {
+		return Artist{}, err
+	}
+
+	var a Artist
+	err = c.get(ctx, endpoint("artists", string(id)), &a)
+	if err != nil {
+		return Artist{}, err
+	}
+
+	return a, nil
+}
+
+// GetArtists returns the artists with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-multiple-artists
+// The artists are in the same order as the ids, unknown ids result in a nil artist.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetArtists(ctx context.Context, ids []spotifyuri.ID) ([]*Artist, error) {
+	out := make([]*Artist, len(ids))
+	err := batch(ctx, spotifyuri.KindArtist, ids, maxArtistsPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Artists []*Artist `json:"artists"`
+		}
+		err := c.get(ctx, endpoint("artists")+idsQuery(chunk, ""), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Artists)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// AlbumGroup is the relation of an artist to an album.
+type AlbumGroup string
+
+const (
+	AlbumGroupAlbum       AlbumGroup = "album"
+	AlbumGroupSingle      AlbumGroup = "single"
+	AlbumGroupAppearsOn   AlbumGroup = "appears_on"
+	AlbumGroupCompilation AlbumGroup = "compilation"
+)
+
+func (g AlbumGroup) valid() bool {
+	switch g {
+	case AlbumGroupAlbum, AlbumGroupSingle, AlbumGroupAppearsOn, AlbumGroupCompilation:
+		return true
+	}
+
+	return false
+}
+
+// ArtistAlbumsOptions are the optional parameters of GetArtistAlbums.
+type ArtistAlbumsOptions struct {
+	// IncludeGroups filters the albums by their relation to the artist, all groups are returned if empty.
+	IncludeGroups []AlbumGroup
+	// Market is an ISO 3166-1 alpha-2 country code, only albums available in this market are returned.
+	Market string
+	PageOptions
+}
+
+func artistAlbumsURL(id spotifyuri.ID, opts ArtistAlbumsOptions) (string, error) {
+	err := validateID(spotifyuri.KindArtist, id)
+	if err != nil {
+		return "", err
+	}
+
+	err = opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	if len(opts.IncludeGroups) > 0 {
+		groups := make([]string, 0, len(opts.IncludeGroups))
+		for _, g := range opts.IncludeGroups {
+			if !g.valid() {
+				return "", newError(invalidInputs, "unknown album group '"+string(g)+"'", nil)
+			}
+			groups = append(groups, string(g))
+		}
+		q.Set("include_groups", strings.Join(groups, ","))
+	}
+	if opts.Market != "" {
+		q.Set("market", opts.Market)
+	}
+	opts.apply(q)
+
+	u := endpoint("artists", string(id), "albums")
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u, nil
+}
+
+// GetArtistAlbums returns a page of the albums of an artist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-artists-albums
+func (c *Client) GetArtistAlbums(ctx context.Context, id spotifyuri.ID, opts ArtistAlbumsOptions) (SimpleAlbumPage, error) {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return SimpleAlbumPage{}, err
+	}
+	opts.Market = market
+
+	u, err := artistAlbumsURL(id, opts)
+	if err != nil {
+		return SimpleAlbumPage{}, err
+	}
+
+	var page SimpleAlbumPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SimpleAlbumPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateArtistAlbums returns a paginator over all albums of an artist, the pages are of type SimpleAlbumPage.
+func (c *Client) PaginateArtistAlbums(ctx context.Context, id spotifyuri.ID, opts ArtistAlbumsOptions) *Paginator {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+	opts.Market = market
+
+	u, err := artistAlbumsURL(id, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// GetArtistTopTracks returns the top tracks of an artist in the given market as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-artists-top-tracks
+// The market is required, either per call or as default market of the client, see SetMarket.
+func (c *Client) GetArtistTopTracks(ctx context.Context, id spotifyuri.ID, market string) ([]Track, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateID(spotifyuri.KindArtist, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if market == "" {
+		return nil, newError(invalidInputs, "market is required", nil)
+	}
+
+	var resp struct {
+		Tracks []Track `json:"tracks"`
+	}
+	err = c.get(ctx, endpoint("artists", string(id), "top-tracks")+marketQuery(market), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tracks, nil
+}
+
+// GetRelatedArtists returns artists similar to the given one as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-artists-related-artists
+func (c *Client) GetRelatedArtists(ctx context.Context, id spotifyuri.ID) ([]Artist, error) {
+	err := validateID(spotifyuri.KindArtist, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Artists []Artist `json:"artists"`
+	}
+	err = c.get(ctx, endpoint("artists", string(id), "related-artists"), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Artists, nil
+}
@@ -0,0 +1,105 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestGetArtistAlbums(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		opts          ArtistAlbumsOptions
+		expectedError error
+		expectedURL   string
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"unknown group -- should fail": {
+			id:   mockID,
+			opts: ArtistAlbumsOptions{IncludeGroups: []AlbumGroup{"mock"}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no options": {
+			id:          mockID,
+			expectedURL: baseURL + "/artists/" + mockID + "/albums",
+		},
+		"all options": {
+			id: mockID,
+			opts: ArtistAlbumsOptions{
+				IncludeGroups: []AlbumGroup{AlbumGroupAlbum, AlbumGroupSingle},
+				Market:        "DE",
+				PageOptions:   PageOptions{Limit: 10, Offset: 20},
+			},
+			expectedURL: baseURL + "/artists/" + mockID + "/albums?include_groups=album%2Csingle&limit=10&market=DE&offset=20",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, SimpleAlbumPage{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			_, err := c.GetArtistAlbums(context.Background(), tc.id, tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if tc.expectedURL != "" && httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestGetArtistTopTracks(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		market        string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			market: "DE",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no market -- should fail": {
+			id: mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"valid request": {
+			id:     mockID,
+			market: "DE",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, map[string][]Track{"tracks": {{ID: "track"}}})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			got, err := c.GetArtistTopTracks(context.Background(), tc.id, tc.market)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			want := baseURL + "/artists/" + mockID + "/top-tracks?market=DE"
+			if httpClient.gotRequest.URL.String() != want {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), want)
+			}
+
+			if len(got) != 1 || got[0].ID != "track" {
+				t.Errorf("spotify.Client.GetArtistTopTracks() unexpected tracks '%+v'", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// Person is an author or narrator of an audiobook.
+type Person struct {
+	Name string `json:"name"`
+}
+
+// SimpleAudiobook is the simplified audiobook object, as it is returned e.g. by a search.
+type SimpleAudiobook struct {
+	Authors          []Person     `json:"authors"`
+	AvailableMarkets []string     `json:"available_markets"`
+	Copyrights       []Copyright  `json:"copyrights"`
+	Description      string       `json:"description"`
+	Edition          string       `json:"edition"`
+	Explicit         bool         `json:"explicit"`
+	ExternalURLs     ExternalURLs `json:"external_urls"`
+	Href             string       `json:"href"`
+	HTMLDescription  string       `json:"html_description"`
+	ID               string       `json:"id"`
+	Images           []Image      `json:"images"`
+	Languages        []string     `json:"languages"`
+	MediaType        string       `json:"media_type"`
+	Name             string       `json:"name"`
+	Narrators        []Person     `json:"narrators"`
+	Publisher        string       `json:"publisher"`
+	TotalChapters    int          `json:"total_chapters"`
+	Type             string       `json:"type"`
+	URI              string       `json:"uri"`
+}
+
+// SimpleAudiobookPage is a page of simplified audiobooks, e.g. of a search.
+type SimpleAudiobookPage struct {
+	Href  string            `json:"href"`
+	Items []SimpleAudiobook `json:"items"`
+	Pagination
+}
+
+// Audiobook is the full audiobook object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-audiobook
+type Audiobook struct {
+	SimpleAudiobook
+	// Chapters is the first page of the chapters, use GetAudiobookChapters for the others.
+	Chapters SimpleChapterPage `json:"chapters"`
+}
+
+// maxAudiobooksPerRequest is the maximum number of ids of a single request to the several audiobooks endpoint.
+const maxAudiobooksPerRequest = 50
+
+// GetAudiobook returns the audiobook with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-audiobook
+// Audiobooks are only available in some markets, the market is optional.
+func (c *Client) GetAudiobook(ctx context.Context, id spotifyuri.ID, market string) (Audiobook, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Audiobook{}, err
+	}
+
+	err = validateID(spotifyuri.KindAudiobook, id)
+	if err != nil {
+		return Audiobook{}, err
+	}
+
+	var a Audiobook
+	err = c.get(ctx, endpoint("audiobooks", string(id))+marketQuery(market), &a)
+	if err != nil {
+		return Audiobook{}, err
+	}
+
+	return a, nil
+}
+
+// GetAudiobooks returns the audiobooks with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-multiple-audiobooks
+// The audiobooks are in the same order as the ids, unknown ids result in a nil audiobook.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetAudiobooks(ctx context.Context, ids []spotifyuri.ID, market string) ([]*Audiobook, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Audiobook, len(ids))
+	err = batch(ctx, spotifyuri.KindAudiobook, ids, maxAudiobooksPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Audiobooks []*Audiobook `json:"audiobooks"`
+		}
+		err := c.get(ctx, endpoint("audiobooks")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Audiobooks)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func audiobookChaptersURL(id spotifyuri.ID, market string, opts PageOptions) (string, error) {
+	err := validateID(spotifyuri.KindAudiobook, id)
+	if err != nil {
+		return "", err
+	}
+
+	return pagedURL(endpoint("audiobooks", string(id), "chapters"), market, opts)
+}
+
+// GetAudiobookChapters returns a page of the chapters of an audiobook as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-audiobook-chapters
+func (c *Client) GetAudiobookChapters(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) (SimpleChapterPage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return SimpleChapterPage{}, err
+	}
+
+	u, err := audiobookChaptersURL(id, market, opts)
+	if err != nil {
+		return SimpleChapterPage{}, err
+	}
+
+	var page SimpleChapterPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SimpleChapterPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateAudiobookChapters returns a paginator over all chapters of an audiobook, the pages are of type SimpleChapterPage.
+func (c *Client) PaginateAudiobookChapters(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := audiobookChaptersURL(id, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
@@ -0,0 +1,198 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// mockMarketBatchApi is like mockBatchApi, but records the market of every request as well.
+func mockMarketBatchApi(t *testing.T, key string, requests *[]int, markets *[]string) mockHttpClientFunc {
+	var mu sync.Mutex
+	api := mockBatchApi(t, key, requests)
+	return func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		*markets = append(*markets, r.URL.Query().Get("market"))
+		mu.Unlock()
+
+		return api(r)
+	}
+}
+
+func TestGetAudiobook(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		market        string
+		defaultMarket string
+		expectedURL   string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid market -- should fail": {
+			id:     mockID,
+			market: "de",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"without market": {
+			id:          mockID,
+			expectedURL: baseURL + "/audiobooks/" + mockID,
+		},
+		"default market": {
+			id:            mockID,
+			defaultMarket: "DE",
+			expectedURL:   baseURL + "/audiobooks/" + mockID + "?market=DE",
+		},
+		"market of the call": {
+			id:            mockID,
+			market:        "SE",
+			defaultMarket: "DE",
+			expectedURL:   baseURL + "/audiobooks/" + mockID + "?market=SE",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: mockTokenResponse(`{"id": "` + mockID + `", "name": "mock", "chapters": {"items": [{"id": "chapter"}], "total": 1}}`)}
+			c := mockAuthorizedClient(Client{httpClient: httpClient, market: tc.defaultMarket})
+
+			got, err := c.GetAudiobook(context.Background(), tc.id, tc.market)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+
+			if got.ID != mockID || len(got.Chapters.Items) != 1 || got.Chapters.Items[0].ID != "chapter" {
+				t.Errorf("spotify.Client.GetAudiobook() unexpected audiobook '%+v'", got)
+			}
+		})
+	}
+}
+
+func TestGetAudiobooks(t *testing.T) {
+	var (
+		requests []int
+		markets  []string
+	)
+	c := mockAuthorizedClient(Client{httpClient: mockMarketBatchApi(t, "audiobooks", &requests, &markets)})
+
+	ids := append(mockIDs(120), mockUnknownID)
+	got, err := c.GetAudiobooks(context.Background(), ids, "DE")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetAudiobooks() unexpected error '%s'", err.Error())
+	}
+
+	// the chunks are requested concurrently, so the order of the requests is not fixed:
+	sort.Sort(sort.Reverse(sort.IntSlice(requests)))
+
+	if diff := cmp.Diff([]int{50, 50, 21}, requests); diff != "" {
+		t.Errorf("spotify.Client.GetAudiobooks() chunk mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"DE", "DE", "DE"}, markets); diff != "" {
+		t.Errorf("spotify.Client.GetAudiobooks() market mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(got) != len(ids) || got[119] == nil || got[119].ID != string(ids[119]) {
+		t.Errorf("spotify.Client.GetAudiobooks() unexpected audiobook '%+v'", got[119])
+	}
+
+	if got[120] != nil {
+		t.Errorf("spotify.Client.GetAudiobooks() expected nil for an unknown id, got '%+v'", got[120])
+	}
+
+	_, err = c.GetAudiobooks(context.Background(), []spotifyuri.ID{"1234"}, "")
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
+
+func TestGetAudiobookChapters(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		market        string
+		opts          PageOptions
+		expectedURL   string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"negative limit -- should fail": {
+			id:   mockID,
+			opts: PageOptions{Limit: -1},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid market -- should fail": {
+			id:     mockID,
+			market: "Germany",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"market and page": {
+			id:          mockID,
+			market:      "DE",
+			opts:        PageOptions{Limit: 50, Offset: 50},
+			expectedURL: baseURL + "/audiobooks/" + mockID + "/chapters?limit=50&market=DE&offset=50",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, SimpleChapterPage{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			_, err := c.GetAudiobookChapters(context.Background(), tc.id, tc.market, tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if tc.expectedURL != "" && httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestPaginateAudiobookChapters(t *testing.T) {
+	var markets []string
+	api := mockPagedApi(t, 5, true, "")
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		markets = append(markets, r.URL.Query().Get("market"))
+		return api(r)
+	})})
+
+	// the mocked items are strings, the paging is what matters here:
+	var got []string
+	err := c.PaginateAudiobookChapters(context.Background(), mockID, "DE", PageOptions{Limit: 2}).Collect(&got)
+	if err != nil {
+		t.Fatalf("spotify.Client.PaginateAudiobookChapters() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(wantItems(0, 5), got); diff != "" {
+		t.Errorf("spotify.Client.PaginateAudiobookChapters() mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"DE", "DE", "DE"}, markets); diff != "" {
+		t.Errorf("spotify.Client.PaginateAudiobookChapters() market mismatch (-want +got):\n%s", diff)
+	}
+
+	err = c.PaginateAudiobookChapters(context.Background(), "", "", PageOptions{}).Err()
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
@@ -0,0 +1,178 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// Mode is the modality of a track, either major or minor.
+type Mode int
+
+const (
+	ModeMinor Mode = 0
+	ModeMajor Mode = 1
+)
+
+// AudioFeatures are the audio features of a track described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-audio-features
+type AudioFeatures struct {
+	Acousticness     float64 `json:"acousticness"`
+	AnalysisURL      string  `json:"analysis_url"`
+	Danceability     float64 `json:"danceability"`
+	DurationMs       int     `json:"duration_ms"`
+	Energy           float64 `json:"energy"`
+	ID               string  `json:"id"`
+	Instrumentalness float64 `json:"instrumentalness"`
+	// Key is the pitch class of the track, e.g. 0 = C, 1 = C♯/D♭, ... and -1 if no key was detected.
+	Key           int     `json:"key"`
+	Liveness      float64 `json:"liveness"`
+	Loudness      float64 `json:"loudness"`
+	Mode          Mode    `json:"mode"`
+	Speechiness   float64 `json:"speechiness"`
+	Tempo         float64 `json:"tempo"`
+	TimeSignature int     `json:"time_signature"`
+	TrackHref     string  `json:"track_href"`
+	Type          string  `json:"type"`
+	URI           string  `json:"uri"`
+	// Valence describes the musical positiveness of a track from 0.0 (sad, angry) to 1.0 (happy, cheerful).
+	Valence float64 `json:"valence"`
+}
+
+// AudioAnalysis is the low-level audio analysis of a track described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-audio-analysis
+type AudioAnalysis struct {
+	Meta     AnalysisMeta   `json:"meta"`
+	Track    AnalysisTrack  `json:"track"`
+	Bars     []TimeInterval `json:"bars"`
+	Beats    []TimeInterval `json:"beats"`
+	Sections []Section      `json:"sections"`
+	Segments []Segment      `json:"segments"`
+	Tatums   []TimeInterval `json:"tatums"`
+}
+
+// AnalysisMeta are the information about the analysis itself.
+type AnalysisMeta struct {
+	AnalyzerVersion string  `json:"analyzer_version"`
+	Platform        string  `json:"platform"`
+	DetailedStatus  string  `json:"detailed_status"`
+	StatusCode      int     `json:"status_code"`
+	Timestamp       int64   `json:"timestamp"`
+	AnalysisTime    float64 `json:"analysis_time"`
+	InputProcess    string  `json:"input_process"`
+}
+
+// AnalysisTrack are the overall values of the analyzed track.
+type AnalysisTrack struct {
+	NumSamples              int     `json:"num_samples"`
+	Duration                float64 `json:"duration"`
+	OffsetSeconds           float64 `json:"offset_seconds"`
+	WindowSeconds           float64 `json:"window_seconds"`
+	AnalysisSampleRate      int     `json:"analysis_sample_rate"`
+	AnalysisChannels        int     `json:"analysis_channels"`
+	EndOfFadeIn             float64 `json:"end_of_fade_in"`
+	StartOfFadeOut          float64 `json:"start_of_fade_out"`
+	Loudness                float64 `json:"loudness"`
+	Tempo                   float64 `json:"tempo"`
+	TempoConfidence         float64 `json:"tempo_confidence"`
+	TimeSignature           int     `json:"time_signature"`
+	TimeSignatureConfidence float64 `json:"time_signature_confidence"`
+	Key                     int     `json:"key"`
+	KeyConfidence           float64 `json:"key_confidence"`
+	Mode                    Mode    `json:"mode"`
+	ModeConfidence          float64 `json:"mode_confidence"`
+}
+
+// TimeInterval is a bar, beat or tatum of the analysis. Start and duration are in seconds.
+type TimeInterval struct {
+	Start      float64 `json:"start"`
+	Duration   float64 `json:"duration"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Section is a large variation in rhythm or timbre of a track, e.g. the chorus or a guitar solo.
+type Section struct {
+	TimeInterval
+	Loudness                float64 `json:"loudness"`
+	Tempo                   float64 `json:"tempo"`
+	TempoConfidence         float64 `json:"tempo_confidence"`
+	Key                     int     `json:"key"`
+	KeyConfidence           float64 `json:"key_confidence"`
+	Mode                    Mode    `json:"mode"`
+	ModeConfidence          float64 `json:"mode_confidence"`
+	TimeSignature           int     `json:"time_signature"`
+	TimeSignatureConfidence float64 `json:"time_signature_confidence"`
+}
+
+// Segment is a part of a track with a roughly consistent sound.
+type Segment struct {
+	TimeInterval
+	LoudnessStart   float64   `json:"loudness_start"`
+	LoudnessMax     float64   `json:"loudness_max"`
+	LoudnessMaxTime float64   `json:"loudness_max_time"`
+	LoudnessEnd     float64   `json:"loudness_end"`
+	Pitches         []float64 `json:"pitches"`
+	Timbre          []float64 `json:"timbre"`
+}
+
+// maxAudioFeaturesPerRequest is the maximum number of ids of a single request to the several audio features endpoint.
+const maxAudioFeaturesPerRequest = 100
+
+// GetAudioFeatures returns the audio features of the track with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-audio-features
+func (c *Client) GetAudioFeatures(ctx context.Context, id spotifyuri.ID) (AudioFeatures, error) {
+	err := validateID(spotifyuri.KindTrack, id)
+	if err != nil {
+		return AudioFeatures{}, err
+	}
+
+	var f AudioFeatures
+	err = c.get(ctx, endpoint("audio-features", string(id)), &f)
+	if err != nil {
+		return AudioFeatures{}, err
+	}
+
+	return f, nil
+}
+
+// GetAudioFeaturesBatch returns the audio features of the tracks with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-several-audio-features
+// The features are in the same order as the ids, unknown ids result in nil features.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetAudioFeaturesBatch(ctx context.Context, ids []spotifyuri.ID) ([]*AudioFeatures, error) {
+	out := make([]*AudioFeatures, len(ids))
+	err := batch(ctx, spotifyuri.KindTrack, ids, maxAudioFeaturesPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			AudioFeatures []*AudioFeatures `json:"audio_features"`
+		}
+		err := c.get(ctx, endpoint("audio-features")+idsQuery(chunk, ""), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.AudioFeatures)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetAudioAnalysis returns the audio analysis of the track with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-audio-analysis
+func (c *Client) GetAudioAnalysis(ctx context.Context, id spotifyuri.ID) (AudioAnalysis, error) {
+	err := validateID(spotifyuri.KindTrack, id)
+	if err != nil {
+		return AudioAnalysis{}, err
+	}
+
+	var a AudioAnalysis
+	err = c.get(ctx, endpoint("audio-analysis", string(id)), &a)
+	if err != nil {
+		return AudioAnalysis{}, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,148 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestGetAudioFeatures(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		httpClient    HttpClient
+		expected      AudioFeatures
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"id of an album -- should fail": {
+			id: "spotify:album:" + mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"unknown track -- return the error": {
+			id: mockUnknownID,
+			httpClient: &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusNotFound, map[string]interface{}{
+				"error": map[string]interface{}{"status": http.StatusNotFound, "message": "analysis not found"},
+			})},
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"valid response -- return the features": {
+			id: mockID,
+			httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+				if r.URL.String() != baseURL+"/audio-features/"+mockID {
+					t.Errorf("unexpected url '%s'", r.URL)
+				}
+				return mockTokenResponse(`{"id": "` + mockID + `", "danceability": 0.7, "key": -1, "mode": 1, "tempo": 120.5}`), nil
+			}),
+			expected: AudioFeatures{ID: mockID, Danceability: 0.7, Key: -1, Mode: ModeMajor, Tempo: 120.5},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			c := mockAuthorizedClient(Client{httpClient: tc.httpClient})
+
+			got, err := c.GetAudioFeatures(context.Background(), tc.id)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("spotify.Client.GetAudioFeatures() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAudioFeaturesBatch(t *testing.T) {
+	var requests []int
+	c := mockAuthorizedClient(Client{httpClient: mockBatchApi(t, "audio_features", &requests)})
+
+	ids := append(mockIDs(150), mockUnknownID)
+	got, err := c.GetAudioFeaturesBatch(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("spotify.Client.GetAudioFeaturesBatch() unexpected error '%s'", err.Error())
+	}
+
+	if len(requests) != 2 {
+		t.Errorf("spotify.Client.GetAudioFeaturesBatch() expected '2' requests, got '%d'", len(requests))
+	}
+
+	if got[149] == nil || got[149].ID != string(ids[149]) {
+		t.Errorf("spotify.Client.GetAudioFeaturesBatch() unexpected features '%+v'", got[149])
+	}
+
+	if got[150] != nil {
+		t.Errorf("spotify.Client.GetAudioFeaturesBatch() expected nil for an unknown id, got '%+v'", got[150])
+	}
+}
+
+func TestGetAudioAnalysis(t *testing.T) {
+	body := `{
+		"track": {"tempo": 120.5, "key": 9, "mode": 0},
+		"beats": [{"start": 0.5, "duration": 0.49, "confidence": 0.8}],
+		"sections": [{"start": 0, "duration": 10.2, "confidence": 1, "tempo": 119.9, "key": 9, "mode": 1}]
+	}`
+
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		httpClient    HttpClient
+		expected      AudioAnalysis
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"request failed -- return the error": {
+			id:         mockID,
+			httpClient: &mockHttpClient{expectedError: errMock},
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"valid response -- return the typed analysis": {
+			id: mockID,
+			httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+				if !strings.HasSuffix(r.URL.Path, "/audio-analysis/"+mockID) {
+					t.Errorf("unexpected path '%s'", r.URL.Path)
+				}
+				return mockTokenResponse(body), nil
+			}),
+			expected: AudioAnalysis{
+				Track: AnalysisTrack{Tempo: 120.5, Key: 9, Mode: ModeMinor},
+				Beats: []TimeInterval{{Start: 0.5, Duration: 0.49, Confidence: 0.8}},
+				Sections: []Section{{
+					TimeInterval: TimeInterval{Duration: 10.2, Confidence: 1},
+					Tempo:        119.9,
+					Key:          9,
+					Mode:         ModeMajor,
+				}},
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			c := mockAuthorizedClient(Client{httpClient: tc.httpClient, retry: RetryPolicy{MaxAttempts: 1}})
+
+			got, err := c.GetAudioAnalysis(context.Background(), tc.id)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("spotify.Client.GetAudioAnalysis() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
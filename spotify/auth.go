@@ -2,24 +2,27 @@ package spotify
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 /**
- * This is currently only implementing the "Client Credentials Flow"
+ * This is implementing the "Client Credentials Flow"
  * ref.: https://developer.spotify.com/documentation/general/guides/authorization/client-credentials/
- * The "Authorization Code Flow" will be needed to access actual user related resources.
- * ref.: https://developer.spotify.com/documentation/general/guides/authorization/code-flow/
- * @TODO: add the flow to access user resources via token.
+ * The "Authorization Code Flow with PKCE", which is needed to access actual user related resources,
+ * can be found in pkce.go.
+ * ref.: https://developer.spotify.com/documentation/general/guides/authorization/code-pkce-flow/
  */
 
 const (
-	baseTokenURL = "https://accounts.spotify.com/api/token"
-	successCode  = http.StatusOK
+	baseAccountsURL = "https://accounts.spotify.com"
+	baseTokenURL    = baseAccountsURL + "/api/token"
+	successCode     = http.StatusOK
 )
 
 type HttpClient interface {
@@ -31,21 +34,34 @@ func createAuthHeader(id, secret string) string {
 	return fmt.Sprintf("Basic %s", clientCredentials)
 }
 
-func createAuthRequest(id, secret string) (*http.Request, error) {
-	req, err := http.NewRequest(http.MethodPost, baseTokenURL, bytes.NewBufferString("grant_type=client_credentials"))
+// createTokenRequest creates a request against the token endpoint of the accounts service.
+// Every flow is using the same endpoint and only differs in the form values that are sent.
+// The auth header is optional, since e.g. the PKCE flow is not relying on the client secret.
+func createTokenRequest(ctx context.Context, tokenURL string, form url.Values, authHeader string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewBufferString(form.Encode()))
 	if err != nil {
 		return &http.Request{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Add("Authorization", createAuthHeader(id, secret))
+	if authHeader != "" {
+		req.Header.Add("Authorization", authHeader)
+	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Accept", "application/json")
 
 	return req, nil
 }
 
+func createAuthRequest(ctx context.Context, tokenURL, id, secret string) (*http.Request, error) {
+	return createTokenRequest(ctx, tokenURL, url.Values{"grant_type": {"client_credentials"}}, createAuthHeader(id, secret))
+}
+
 type authBody struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
 }
 
 func readAuthBody(body io.ReadCloser) (authBody, error) {
@@ -61,35 +77,49 @@ func readAuthBody(body io.ReadCloser) (authBody, error) {
 	return a, nil
 }
 
-// retrieveAuthToken is mostly a port of the client credentials example in node.js to go.
-// It can be found here:
-// https://github.com/spotify/web-api-auth-examples/blob/master/client_credentials/app.js
-// it's relying on the authorization code flow as described here in the docs:
-// https://developer.spotify.com/documentation/general/guides/authorization/code-flow/
-func retrieveAuthToken(httpClient HttpClient, id, secret string) (string, error) {
+// requestToken executes a request against the token endpoint and parses the response.
+func requestToken(httpClient HttpClient, req *http.Request) (authBody, error) {
 	if httpClient == nil {
-		return "", fmt.Errorf("http client can not be nil")
-	}
-
-	req, err := createAuthRequest(id, secret)
-	if err != nil {
-		return "", fmt.Errorf("failed to create auth request, %w", err)
+		return authBody{}, fmt.Errorf("http client can not be nil")
 	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute auth request, %w", err)
+		return authBody{}, fmt.Errorf("failed to execute auth request, %w", checkCanceled(req.Context(), err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != successCode {
-		return "", fmt.Errorf("got unexpected status code '%d', want: '%d'", resp.StatusCode, successCode)
+		return authBody{}, fmt.Errorf("got unexpected status code '%d', want: '%d'", resp.StatusCode, successCode)
 	}
 
 	body, err := readAuthBody(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read auth body, %w", err)
+		return authBody{}, fmt.Errorf("failed to read auth body, %w", err)
+	}
+
+	return body, nil
+}
+
+// retrieveAuthToken is mostly a port of the client credentials example in node.js to go.
+// It can be found here:
+// https://github.com/spotify/web-api-auth-examples/blob/master/client_credentials/app.js
+// it's relying on the client credentials flow as described here in the docs:
+// https://developer.spotify.com/documentation/general/guides/authorization/client-credentials/
+func retrieveAuthToken(ctx context.Context, httpClient HttpClient, tokenURL, id, secret string) (Token, error) {
+	if httpClient == nil {
+		return Token{}, fmt.Errorf("http client can not be nil")
+	}
+
+	req, err := createAuthRequest(ctx, tokenURL, id, secret)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to create auth request, %w", err)
+	}
+
+	body, err := requestToken(httpClient, req)
+	if err != nil {
+		return Token{}, err
 	}
 
-	return body.AccessToken, nil
+	return body.token(), nil
 }
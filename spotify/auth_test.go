@@ -2,6 +2,7 @@ package spotify
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -67,7 +68,7 @@ func TestReadAuthBody(t *testing.T) {
 			}
 
 			if tc.expected.AccessToken != got.AccessToken {
-				t.Errorf("unexpected result, \n - got: '%s', \n - want: '%s'", got, tc.expected)
+				t.Errorf("unexpected result, \n - got: '%s', \n - want: '%s'", got.AccessToken, tc.expected.AccessToken)
 			}
 		})
 	}
@@ -76,12 +77,24 @@ func TestReadAuthBody(t *testing.T) {
 type mockHttpClient struct {
 	expectedResponse *http.Response
 	expectedError    error
+
+	// gotRequest holds the last request passed to Do, so tests can inspect it.
+	gotRequest *http.Request
 }
 
 func (m *mockHttpClient) Do(r *http.Request) (*http.Response, error) {
+	m.gotRequest = r
 	return m.expectedResponse, m.expectedError
 }
 
+// mockHttpClientFunc is used by tests that need to answer multiple requests differently,
+// e.g. a token refresh followed by the actual api request.
+type mockHttpClientFunc func(r *http.Request) (*http.Response, error)
+
+func (m mockHttpClientFunc) Do(r *http.Request) (*http.Response, error) {
+	return m(r)
+}
+
 func TestRetrieveAuthToken(t *testing.T) {
 	testcases := map[string]struct {
 		httpClient  HttpClient
@@ -128,15 +141,15 @@ func TestRetrieveAuthToken(t *testing.T) {
 
 	for testName, tc := range testcases {
 		t.Run(testName, func(t *testing.T) {
-			got, err := retrieveAuthToken(tc.httpClient, tc.id, tc.secret)
+			got, err := retrieveAuthToken(context.Background(), tc.httpClient, baseTokenURL, tc.id, tc.secret)
 			if err != nil && !tc.shouldError {
 				t.Errorf("TestRetrieveAuthToken() got unexpected error '%s'", err.Error())
 			} else if err == nil && tc.shouldError {
 				t.Errorf("TestRetrieveAuthToken() did not throw an error as expected")
 			}
 
-			if tc.want != got {
-				t.Errorf("unexpected result, \n - got: '%s', \n - want: '%s'", got, tc.want)
+			if tc.want != got.AccessToken {
+				t.Errorf("unexpected result, \n - got: '%s', \n - want: '%s'", got.AccessToken, tc.want)
 			}
 		})
 	}
@@ -0,0 +1,118 @@
+package spotify
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// maxConcurrentBatches limits the number of requests a single batch lookup is sending at the same time,
+// so large lookups are not running straight into the rate limit.
+const maxConcurrentBatches = 4
+
+// batch splits the ids into chunks of at most size ids and calls fetch for every chunk concurrently.
+// fetch gets the offset of the chunk inside of ids, so it can store its results at the right position.
+// The ids have to be valid ids of the given kind. The first error cancels the remaining chunks and is returned.
+func batch(ctx context.Context, kind spotifyuri.Kind, ids []spotifyuri.ID, size int, fetch func(ctx context.Context, offset int, chunk []spotifyuri.ID) error) error {
+	if len(ids) == 0 {
+		return newError(invalidInputs, "at least one id is required", nil)
+	}
+
+	for _, id := range ids {
+		err := validateID(kind, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, maxConcurrentBatches)
+	)
+
+	for offset := 0; offset < len(ids); offset += size {
+		end := offset + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		// a previous chunk failed or the caller canceled, so there is no need to start more requests:
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(offset int, chunk []spotifyuri.ID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := fetch(ctx, offset, chunk)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(offset, ids[offset:end])
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// the context of the caller was done before all chunks were started:
+	return checkCanceled(ctx, ctx.Err())
+}
+
+// idStrings converts the ids to plain strings.
+func idStrings(ids []spotifyuri.ID) []string {
+	out := make([]string, len(ids))
+	for i, id := range ids {
+		out[i] = string(id)
+	}
+	return out
+}
+
+// joinIDs returns the ids as comma separated list, as spotify expects them in queries.
+func joinIDs(ids []spotifyuri.ID) string {
+	return strings.Join(idStrings(ids), ",")
+}
+
+// idsQuery returns the query string for the ids of a batch lookup and the optional market.
+func idsQuery(ids []spotifyuri.ID, market string) string {
+	q := url.Values{"ids": {joinIDs(ids)}}
+	if market != "" {
+		q.Set("market", market)
+	}
+
+	return "?" + q.Encode()
+}
+
+// validateID checks that the id is a valid id of an object of the given kind.
+func validateID(kind spotifyuri.Kind, id spotifyuri.ID) error {
+	if id == "" {
+		return newError(invalidInputs, string(kind)+" id is required", nil)
+	}
+
+	err := id.Validate(kind)
+	if err != nil {
+		return newError(invalidInputs, "invalid "+string(kind)+" id", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// mockIDs returns n valid ids, "id00000000000000000000" to "id<n-1>" padded with zeros.
+func mockIDs(n int) []spotifyuri.ID {
+	ids := make([]spotifyuri.ID, n)
+	for i := range ids {
+		ids[i] = spotifyuri.ID(fmt.Sprintf("id%020d", i))
+	}
+	return ids
+}
+
+// mockUnknownID is a valid id, which mockBatchApi answers with null.
+const mockUnknownID = "unknown000000000000000"
+
+// mockBatchApi is answering several-objects requests with one object per id of the request
+// wrapped into the given key, ids starting with "unknown" are answered with null.
+func mockBatchApi(t *testing.T, key string, requests *[]int) mockHttpClientFunc {
+	var mu sync.Mutex
+	return func(r *http.Request) (*http.Response, error) {
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+
+		mu.Lock()
+		*requests = append(*requests, len(ids))
+		mu.Unlock()
+
+		items := make([]interface{}, len(ids))
+		for i, id := range ids {
+			if !strings.HasPrefix(id, "unknown") {
+				items[i] = map[string]string{"id": id}
+			}
+		}
+
+		return createMockedHttpResponse(t, http.StatusOK, map[string]interface{}{key: items}), nil
+	}
+}
+
+func TestGetTracks(t *testing.T) {
+	testcases := map[string]struct {
+		ids              []spotifyuri.ID
+		httpClient       HttpClient
+		expectedRequests int
+		expectedError    error
+	}{
+		"no ids -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"empty id -- should fail": {
+			ids: []spotifyuri.ID{mockID, ""},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid id -- should fail": {
+			ids: []spotifyuri.ID{mockID, "1234"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"request failed -- return the error": {
+			ids:        mockIDs(3),
+			httpClient: &mockHttpClient{expectedError: errMock},
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"single request": {
+			ids:              mockIDs(50),
+			expectedRequests: 1,
+		},
+		"split into chunks of 50": {
+			ids:              mockIDs(120),
+			expectedRequests: 3,
+		},
+		"unknown ids are nil": {
+			ids:              []spotifyuri.ID{mockIDs(1)[0], mockUnknownID, mockIDs(3)[2]},
+			expectedRequests: 1,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var requests []int
+			httpClient := tc.httpClient
+			if httpClient == nil {
+				httpClient = mockBatchApi(t, "tracks", &requests)
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient, retry: RetryPolicy{MaxAttempts: 1}})
+
+			got, err := c.GetTracks(context.Background(), tc.ids, "")
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if len(requests) != tc.expectedRequests {
+				t.Errorf("spotify.Client.GetTracks() expected '%d' requests, got '%d'", tc.expectedRequests, len(requests))
+			}
+
+			for _, n := range requests {
+				if n > maxTracksPerRequest {
+					t.Errorf("spotify.Client.GetTracks() requested '%d' ids at once", n)
+				}
+			}
+
+			gotIDs := make([]string, len(got))
+			for i, track := range got {
+				if track != nil {
+					gotIDs[i] = track.ID
+				}
+			}
+
+			want := make([]string, len(tc.ids))
+			for i, id := range tc.ids {
+				if !strings.HasPrefix(string(id), "unknown") {
+					want[i] = string(id)
+				}
+			}
+
+			if diff := cmp.Diff(want, gotIDs); diff != "" {
+				t.Errorf("spotify.Client.GetTracks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAlbumsChunks(t *testing.T) {
+	var requests []int
+	c := mockAuthorizedClient(Client{httpClient: mockBatchApi(t, "albums", &requests)})
+
+	got, err := c.GetAlbums(context.Background(), mockIDs(45), "DE")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetAlbums() unexpected error '%s'", err.Error())
+	}
+
+	// the chunks are requested concurrently, so the order of the requests is not fixed:
+	sort.Sort(sort.Reverse(sort.IntSlice(requests)))
+
+	if diff := cmp.Diff([]int{20, 20, 5}, requests); diff != "" {
+		t.Errorf("spotify.Client.GetAlbums() chunk mismatch (-want +got):\n%s", diff)
+	}
+
+	if got[44] == nil || got[44].ID != string(mockIDs(45)[44]) {
+		t.Errorf("spotify.Client.GetAlbums() unexpected last album '%+v'", got[44])
+	}
+}
+
+func TestBatchConcurrencyLimit(t *testing.T) {
+	var running, maxRunning int32
+	err := batch(context.Background(), spotifyuri.KindTrack, mockIDs(50), 2, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("batch() unexpected error '%s'", err.Error())
+	}
+
+	if maxRunning > maxConcurrentBatches {
+		t.Errorf("batch() expected at most '%d' concurrent chunks, got '%d'", maxConcurrentBatches, maxRunning)
+	}
+}
+
+func TestBatchStopsAfterError(t *testing.T) {
+	var calls int32
+	err := batch(context.Background(), spotifyuri.KindTrack, mockIDs(100), 1, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		atomic.AddInt32(&calls, 1)
+		return newError(requestFailed, "mock", nil)
+	})
+	checkSpotifyError(t, errSpotify{code: requestFailed}, err)
+
+	if calls >= 100 {
+		t.Errorf("batch() expected to stop starting chunks after an error, got '%d' calls", calls)
+	}
+}
+
+func TestInvalidIDs(t *testing.T) {
+	httpClient := &mockHttpClient{}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+	ctx := context.Background()
+	name := "mock"
+
+	_, getPlaylistErr := c.GetPlaylist(ctx, "1234", "")
+	_, getUserErr := c.GetUser(ctx, "a:b")
+	_, containsErr := c.SavedAlbumsContain(ctx, []spotifyuri.ID{"1234"})
+	_, followersErr := c.PlaylistFollowersContain(ctx, mockID, []spotifyuri.ID{"a/b"})
+
+	for name, err := range map[string]error{
+		"GetPlaylist":              getPlaylistErr,
+		"UpdatePlaylist":           c.UpdatePlaylist(ctx, "spotify:playlist:"+mockID, UpdatePlaylistPayload{Name: &name}),
+		"GetUser":                  getUserErr,
+		"SavedAlbumsContain":       containsErr,
+		"Follow":                   c.Follow(ctx, FollowTypeArtist, []spotifyuri.ID{"artist"}),
+		"PlaylistFollowersContain": followersErr,
+	} {
+		if !errors.Is(err, errSpotify{code: invalidInputs}) {
+			t.Errorf("spotify.Client.%s() expected an invalid inputs error, got '%v'", name, err)
+		}
+	}
+
+	if httpClient.gotRequest != nil {
+		t.Errorf("expected no request with invalid ids, got '%s'", httpClient.gotRequest.URL.String())
+	}
+}
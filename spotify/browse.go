@@ -0,0 +1,255 @@
+package spotify
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Category is a category of the browse tab of spotify, e.g. "Mood" or "Workout".
+type Category struct {
+	Href  string  `json:"href"`
+	Icons []Image `json:"icons"`
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+}
+
+// CategoryPage is a page of browse categories.
+type CategoryPage struct {
+	Href  string     `json:"href"`
+	Items []Category `json:"items"`
+	Pagination
+}
+
+// BrowsePlaylists are editorial playlists, e.g. the featured playlists or the playlists of a category.
+type BrowsePlaylists struct {
+	// Message is the localized headline of the featured playlists, e.g. "Monday morning music, coming right up!".
+	Message   string             `json:"message"`
+	Playlists SimplePlaylistPage `json:"playlists"`
+}
+
+// BrowseOptions are the optional parameters of the browse endpoints.
+type BrowseOptions struct {
+	// Country is an ISO 3166-1 alpha-2 country code, e.g. "SE".
+	Country string
+	// Locale is an ISO 639-1 language code and an ISO 3166-1 alpha-2 country code
+	// joined by an underscore, e.g. "es_MX". Not every endpoint is supporting it.
+	Locale string
+	PageOptions
+}
+
+// FeaturedPlaylistsOptions are the optional parameters of GetFeaturedPlaylists.
+type FeaturedPlaylistsOptions struct {
+	// Timestamp is used to get the playlists featured at a specific time of the day,
+	// it defaults to the current time of spotify.
+	Timestamp time.Time
+	BrowseOptions
+}
+
+// browseURL returns the url of a browse endpoint, the locale is only sent to endpoints supporting it.
+// The country and locale are validated like the ones of SetMarket and SetLocale.
+func browseURL(opts BrowseOptions, withLocale bool, q url.Values, segments ...string) (string, error) {
+	err := opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	err = validateCountry(opts.Country)
+	if err != nil {
+		return "", err
+	}
+
+	err = validateLocale(opts.Locale)
+	if err != nil {
+		return "", err
+	}
+
+	if q == nil {
+		q = url.Values{}
+	}
+	if opts.Country != "" {
+		q.Set("country", opts.Country)
+	}
+	if withLocale && opts.Locale != "" {
+		q.Set("locale", opts.Locale)
+	}
+	opts.apply(q)
+
+	u := endpoint(append([]string{"browse"}, segments...)...)
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u, nil
+}
+
+// GetCategories returns a page of the browse categories as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-categories
+func (c *Client) GetCategories(ctx context.Context, opts BrowseOptions) (CategoryPage, error) {
+	opts = c.browseDefaults(opts)
+
+	u, err := browseURL(opts, true, nil, "categories")
+	if err != nil {
+		return CategoryPage{}, err
+	}
+
+	var resp struct {
+		Categories CategoryPage `json:"categories"`
+	}
+	err = c.get(ctx, u, &resp)
+	if err != nil {
+		return CategoryPage{}, err
+	}
+
+	return resp.Categories, nil
+}
+
+// PaginateCategories returns a paginator over all browse categories, the pages are of type CategoryPage.
+func (c *Client) PaginateCategories(ctx context.Context, opts BrowseOptions) *Paginator {
+	opts = c.browseDefaults(opts)
+
+	u, err := browseURL(opts, true, nil, "categories")
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// GetCategory returns a single browse category as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-category
+// Country and locale are optional.
+func (c *Client) GetCategory(ctx context.Context, id, country, locale string) (Category, error) {
+	if id == "" {
+		return Category{}, newError(invalidInputs, "category id is required", nil)
+	}
+
+	u, err := browseURL(c.browseDefaults(BrowseOptions{Country: country, Locale: locale}), true, nil, "categories", id)
+	if err != nil {
+		return Category{}, err
+	}
+
+	var category Category
+	err = c.get(ctx, u, &category)
+	if err != nil {
+		return Category{}, err
+	}
+
+	return category, nil
+}
+
+func categoryPlaylistsURL(id string, opts BrowseOptions) (string, error) {
+	if id == "" {
+		return "", newError(invalidInputs, "category id is required", nil)
+	}
+
+	return browseURL(opts, false, nil, "categories", id, "playlists")
+}
+
+// GetCategoryPlaylists returns a page of the playlists of a browse category as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-categories-playlists
+// The locale is not supported by this endpoint.
+func (c *Client) GetCategoryPlaylists(ctx context.Context, id string, opts BrowseOptions) (BrowsePlaylists, error) {
+	opts = c.browseDefaults(opts)
+
+	u, err := categoryPlaylistsURL(id, opts)
+	if err != nil {
+		return BrowsePlaylists{}, err
+	}
+
+	var playlists BrowsePlaylists
+	err = c.get(ctx, u, &playlists)
+	if err != nil {
+		return BrowsePlaylists{}, err
+	}
+
+	return playlists, nil
+}
+
+// PaginateCategoryPlaylists returns a paginator over all playlists of a browse category,
+// the pages are of type SimplePlaylistPage.
+func (c *Client) PaginateCategoryPlaylists(ctx context.Context, id string, opts BrowseOptions) *Paginator {
+	opts = c.browseDefaults(opts)
+
+	u, err := categoryPlaylistsURL(id, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+func featuredPlaylistsURL(opts FeaturedPlaylistsOptions) (string, error) {
+	q := url.Values{}
+	if !opts.Timestamp.IsZero() {
+		// spotify expects the local time of the user without a zone:
+		q.Set("timestamp", opts.Timestamp.Format("2006-01-02T15:04:05"))
+	}
+
+	return browseURL(opts.BrowseOptions, true, q, "featured-playlists")
+}
+
+// GetFeaturedPlaylists returns a page of the featured playlists as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-featured-playlists
+func (c *Client) GetFeaturedPlaylists(ctx context.Context, opts FeaturedPlaylistsOptions) (BrowsePlaylists, error) {
+	opts.BrowseOptions = c.browseDefaults(opts.BrowseOptions)
+
+	u, err := featuredPlaylistsURL(opts)
+	if err != nil {
+		return BrowsePlaylists{}, err
+	}
+
+	var playlists BrowsePlaylists
+	err = c.get(ctx, u, &playlists)
+	if err != nil {
+		return BrowsePlaylists{}, err
+	}
+
+	return playlists, nil
+}
+
+// PaginateFeaturedPlaylists returns a paginator over all featured playlists, the pages are of type SimplePlaylistPage.
+func (c *Client) PaginateFeaturedPlaylists(ctx context.Context, opts FeaturedPlaylistsOptions) *Paginator {
+	opts.BrowseOptions = c.browseDefaults(opts.BrowseOptions)
+
+	u, err := featuredPlaylistsURL(opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// GetNewReleases returns a page of the newly released albums as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-new-releases
+// The locale is not supported by this endpoint.
+func (c *Client) GetNewReleases(ctx context.Context, opts BrowseOptions) (SimpleAlbumPage, error) {
+	opts = c.browseDefaults(opts)
+
+	u, err := browseURL(opts, false, nil, "new-releases")
+	if err != nil {
+		return SimpleAlbumPage{}, err
+	}
+
+	var resp struct {
+		Albums SimpleAlbumPage `json:"albums"`
+	}
+	err = c.get(ctx, u, &resp)
+	if err != nil {
+		return SimpleAlbumPage{}, err
+	}
+
+	return resp.Albums, nil
+}
+
+// PaginateNewReleases returns a paginator over all new releases, the pages are of type SimpleAlbumPage.
+func (c *Client) PaginateNewReleases(ctx context.Context, opts BrowseOptions) *Paginator {
+	opts = c.browseDefaults(opts)
+
+	u, err := browseURL(opts, false, nil, "new-releases")
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
@@ -0,0 +1,157 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBrowseURLs(t *testing.T) {
+	opts := BrowseOptions{Country: "SE", Locale: "sv_SE", PageOptions: PageOptions{Limit: 10}}
+
+	testcases := map[string]struct {
+		call        func(c *Client) error
+		expectedURL string
+	}{
+		"categories": {
+			call: func(c *Client) error {
+				_, err := c.GetCategories(context.Background(), opts)
+				return err
+			},
+			expectedURL: baseURL + "/browse/categories?country=SE&limit=10&locale=sv_SE",
+		},
+		"category": {
+			call: func(c *Client) error {
+				_, err := c.GetCategory(context.Background(), "mood", "SE", "")
+				return err
+			},
+			expectedURL: baseURL + "/browse/categories/mood?country=SE",
+		},
+		"category playlists without locale": {
+			call: func(c *Client) error {
+				_, err := c.GetCategoryPlaylists(context.Background(), "mood", opts)
+				return err
+			},
+			expectedURL: baseURL + "/browse/categories/mood/playlists?country=SE&limit=10",
+		},
+		"featured playlists with timestamp": {
+			call: func(c *Client) error {
+				_, err := c.GetFeaturedPlaylists(context.Background(), FeaturedPlaylistsOptions{
+					Timestamp:     time.Date(2022, 8, 1, 9, 30, 0, 0, time.UTC),
+					BrowseOptions: opts,
+				})
+				return err
+			},
+			expectedURL: baseURL + "/browse/featured-playlists?country=SE&limit=10&locale=sv_SE&timestamp=2022-08-01T09%3A30%3A00",
+		},
+		"new releases without locale": {
+			call: func(c *Client) error {
+				_, err := c.GetNewReleases(context.Background(), opts)
+				return err
+			},
+			expectedURL: baseURL + "/browse/new-releases?country=SE&limit=10",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, struct{}{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := tc.call(&c)
+			if err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			if httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestBrowseInvalidCountryAndLocale(t *testing.T) {
+	testcases := map[string]func(c *Client) error{
+		"lower case country": func(c *Client) error {
+			_, err := c.GetCategories(context.Background(), BrowseOptions{Country: "se"})
+			return err
+		},
+		"from token as country": func(c *Client) error {
+			_, err := c.GetNewReleases(context.Background(), BrowseOptions{Country: MarketFromToken})
+			return err
+		},
+		"locale with a dash": func(c *Client) error {
+			_, err := c.GetFeaturedPlaylists(context.Background(), FeaturedPlaylistsOptions{BrowseOptions: BrowseOptions{Locale: "es-MX"}})
+			return err
+		},
+		"locale of an endpoint not sending it": func(c *Client) error {
+			_, err := c.GetCategoryPlaylists(context.Background(), "mood", BrowseOptions{Locale: "spanish"})
+			return err
+		},
+		"country argument": func(c *Client) error {
+			_, err := c.GetCategory(context.Background(), "mood", "Sweden", "")
+			return err
+		},
+		"locale argument": func(c *Client) error {
+			_, err := c.GetCategory(context.Background(), "mood", "SE", "sv_se")
+			return err
+		},
+		"paginator": func(c *Client) error {
+			return c.PaginateCategories(context.Background(), BrowseOptions{Country: "SWE"}).Err()
+		},
+	}
+
+	for testName, call := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, struct{}{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := call(&c)
+			checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+
+			if httpClient.gotRequest != nil {
+				t.Errorf("unexpected request '%s'", httpClient.gotRequest.URL.String())
+			}
+		})
+	}
+}
+
+func TestPaginateCategories(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockPagedApi(t, 5, true, "categories")})
+
+	// the mocked items are strings, the envelope and the paging is what matters here:
+	var got []string
+	err := c.PaginateCategories(context.Background(), BrowseOptions{PageOptions: PageOptions{Limit: 2}}).Collect(&got)
+	if err != nil {
+		t.Fatalf("spotify.Client.PaginateCategories() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(wantItems(0, 5), got); diff != "" {
+		t.Errorf("spotify.Client.PaginateCategories() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetCategoryPlaylists(t *testing.T) {
+	want := BrowsePlaylists{
+		Message:   "mock",
+		Playlists: SimplePlaylistPage{Href: "test"},
+	}
+	c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{
+		expectedResponse: createMockedHttpResponse(t, http.StatusOK, want),
+	}})
+
+	_, err := c.GetCategoryPlaylists(context.Background(), "", BrowseOptions{})
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+
+	got, err := c.GetCategoryPlaylists(context.Background(), "mood", BrowseOptions{})
+	if err != nil {
+		t.Fatalf("spotify.Client.GetCategoryPlaylists() unexpected error '%s'", err.Error())
+	}
+
+	if got.Message != want.Message || got.Playlists.Href != want.Playlists.Href {
+		t.Errorf("spotify.Client.GetCategoryPlaylists() mismatch, got '%+v'", got)
+	}
+}
@@ -0,0 +1,202 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLoginTimeout = 5 * time.Minute
+	shutdownTimeout     = 5 * time.Second
+)
+
+// LoginConfig is configuring the local callback server of LoginWithCallback.
+// Every field is optional.
+type LoginConfig struct {
+	// Port the callback server is listening on, defaults to the port of the redirect uri.
+	// Setting it is only needed if the redirect is forwarded to another port, e.g. by an ssh tunnel.
+	Port string
+	// Output is where the url to open is printed to, defaults to os.Stdout.
+	Output io.Writer
+	// Timeout is the time the user has to finish the login, defaults to five minutes.
+	Timeout time.Duration
+}
+
+type callbackResult struct {
+	client Client
+	err    error
+}
+
+// LoginWithCallback runs through the PKCE flow interactively. It starts a short-lived server
+// on the loopback interface that receives the redirect of spotify, prints the url the user has
+// to open and waits until the login is finished. Since the url is printed only, this is also
+// working on headless machines, as long as the browser is able to reach the redirect uri.
+// The RedirectURI of the flow has to point to the local server, e.g. "http://localhost:8080/callback",
+// its host has to be "localhost", "127.0.0.1" or "[::1]", and it has to be registered for the app in the spotify dashboard. The options are configuring the
+// returned client, see PKCEFlow.Exchange.
+func LoginWithCallback(httpClient HttpClient, flow PKCEFlow, user string, conf LoginConfig, opts ...ClientOption) (Client, error) {
+	return LoginWithCallbackContext(context.Background(), httpClient, flow, user, conf, opts...)
+}
+
+// LoginWithCallbackContext is like LoginWithCallback, but the login is aborted
+// once the given context is done.
+func LoginWithCallbackContext(ctx context.Context, httpClient HttpClient, flow PKCEFlow, user string, conf LoginConfig, opts ...ClientOption) (Client, error) {
+	// the options are validated before the user is sent to the browser:
+	_, err := flow.newClient(httpClient, user, opts)
+	if err != nil {
+		return Client{}, err
+	}
+
+	redirect, err := url.Parse(flow.RedirectURI)
+	if err != nil {
+		return Client{}, newError(invalidInputs, "invalid redirect uri", err)
+	}
+
+	host, err := loopbackHost(redirect.Hostname())
+	if err != nil {
+		return Client{}, err
+	}
+
+	port := conf.Port
+	if port == "" {
+		port = redirect.Port()
+	}
+
+	if port == "" {
+		return Client{}, newError(invalidInputs, "redirect uri or config has to specify a port", nil)
+	}
+
+	ln, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return Client{}, newError(internalError, "failed to start callback server", err)
+	}
+
+	return serveCallback(ctx, ln, httpClient, flow, user, conf, opts)
+}
+
+// loopbackHost returns the loopback address the callback server listens on for the host of the redirect uri.
+// Other hosts are rejected, the server must not be reachable from outside of the machine.
+func loopbackHost(host string) (string, error) {
+	switch host {
+	case "localhost", "127.0.0.1":
+		return "127.0.0.1", nil
+	case "::1":
+		return "::1", nil
+	}
+
+	return "", newError(invalidInputs, "redirect uri has to point to localhost, 127.0.0.1 or [::1], got host '"+host+"'", nil)
+}
+
+// serveCallback is serving the callback on the given listener and closes it when done.
+func serveCallback(ctx context.Context, ln net.Listener, httpClient HttpClient, flow PKCEFlow, user string, conf LoginConfig, opts []ClientOption) (Client, error) {
+	out := conf.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = defaultLoginTimeout
+	}
+
+	path := "/"
+	if redirect, err := url.Parse(flow.RedirectURI); err == nil && redirect.Path != "" {
+		path = redirect.Path
+	}
+
+	// buffered, so the handler never blocks if e.g. the browser retries the redirect:
+	results := make(chan callbackResult, 1)
+
+	// lastErr is the error of the last rejected callback, it is reported if the login times out:
+	var (
+		mu      sync.Mutex
+		lastErr error
+	)
+
+	// the path is matched exactly, a redirect uri without path would otherwise
+	// receive e.g. the favicon requests of the browser as well:
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+
+		c, done, err := handleCallback(ctx, r, httpClient, flow, user, opts)
+		if err != nil {
+			http.Error(w, "Login failed, "+err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login successful, you can close this window now.")
+		}
+
+		if !done {
+			mu.Lock()
+			lastErr = err
+			mu.Unlock()
+			return
+		}
+
+		select {
+		case results <- callbackResult{client: c, err: err}:
+		default:
+		}
+	}
+
+	srv := &http.Server{Handler: http.HandlerFunc(handler)}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(out, "Open the following url in your browser to log in to spotify:\n\n%s\n\n", flow.AuthURL())
+
+	select {
+	case res := <-results:
+		return res.client, res.err
+	case <-time.After(timeout):
+		mu.Lock()
+		defer mu.Unlock()
+		return Client{}, newError(notAuthorized, "login timed out", lastErr)
+	case <-ctx.Done():
+		return Client{}, checkCanceled(ctx, ctx.Err())
+	}
+}
+
+// handleCallback exchanges the code of the redirect for a client. done reports if the login is over,
+// which is the case once the code was exchanged or spotify reported an error for this flow. Since the
+// code can only be used once, a failed exchange ends the login as well. Requests with another state
+// or without a code are rejected, but the login keeps waiting for the real redirect.
+func handleCallback(ctx context.Context, r *http.Request, httpClient HttpClient, flow PKCEFlow, user string, opts []ClientOption) (c Client, done bool, err error) {
+	q := r.URL.Query()
+
+	err = flow.ValidateState(q.Get("state"))
+	if err != nil {
+		return Client{}, false, err
+	}
+
+	// the user denied the access or something else went wrong on spotifys side:
+	if e := q.Get("error"); e != "" {
+		return Client{}, true, newError(notAuthorized, "authorization was denied: "+e, nil)
+	}
+
+	if q.Get("code") == "" {
+		return Client{}, false, newError(invalidInputs, "authorization code is missing", nil)
+	}
+
+	c, err = flow.ExchangeContext(ctx, httpClient, q.Get("code"), user, opts...)
+	if err != nil {
+		return Client{}, true, err
+	}
+
+	return c, true, nil
+}
@@ -0,0 +1,231 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLoginWithCallbackInvalidInputs(t *testing.T) {
+	testcases := map[string]struct {
+		flow          PKCEFlow
+		conf          LoginConfig
+		expectedError error
+	}{
+		"redirect uri is invalid -- should fail": {
+			flow: PKCEFlow{RedirectURI: "://invalid"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no port specified -- should fail": {
+			flow: PKCEFlow{RedirectURI: "http://localhost/callback"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"redirect uri on the network -- should fail": {
+			flow: PKCEFlow{RedirectURI: "http://raspberrypi.local:8080/callback"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"redirect uri on all interfaces -- should fail": {
+			flow: PKCEFlow{RedirectURI: "http://0.0.0.0:8080/callback"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"redirect uri without host -- should fail": {
+			flow: PKCEFlow{RedirectURI: "http://:8080/callback"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"accounts url is invalid -- should fail": {
+			flow: PKCEFlow{RedirectURI: "http://localhost:8080/callback", AccountsURL: "localhost:8081"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := LoginWithCallback(&mockHttpClient{}, tc.flow, "user", tc.conf)
+			checkSpotifyError(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLoopbackHost(t *testing.T) {
+	for host, want := range map[string]string{
+		"localhost": "127.0.0.1",
+		"127.0.0.1": "127.0.0.1",
+		"::1":       "::1",
+	} {
+		got, err := loopbackHost(host)
+		if err != nil || got != want {
+			t.Errorf("loopbackHost('%s') unexpected result '%s', error '%v'", host, got, err)
+		}
+	}
+}
+
+func TestServeCallback(t *testing.T) {
+	type request struct {
+		path   string
+		status int
+	}
+
+	testcases := map[string]struct {
+		redirectURI   string
+		tokenResponse *http.Response
+		requests      []request
+		timeout       time.Duration
+		expectedError error
+		expectedToken string
+	}{
+		"exchange of the code failed -- fail without waiting for the timeout": {
+			tokenResponse: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(strings.NewReader(`{"error": "invalid_grant"}`)),
+			},
+			requests: []request{
+				{path: "/callback?code=code&state=state", status: http.StatusBadRequest},
+			},
+			timeout: time.Minute,
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"user denied access -- should fail": {
+			requests: []request{
+				{path: "/callback?error=access_denied&state=state", status: http.StatusBadRequest},
+			},
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"only callbacks with a foreign state -- time out": {
+			requests: []request{
+				{path: "/callback?code=code&state=other", status: http.StatusBadRequest},
+				{path: "/callback?error=access_denied&state=other", status: http.StatusBadRequest},
+			},
+			timeout: time.Second,
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"stray requests are rejected -- keep waiting for the redirect": {
+			requests: []request{
+				{path: "/favicon.ico", status: http.StatusNotFound},
+				{path: "/callback/other?code=code&state=state", status: http.StatusNotFound},
+				{path: "/callback?code=code&state=other", status: http.StatusBadRequest},
+				{path: "/callback?state=state", status: http.StatusBadRequest},
+				{path: "/callback?code=code&state=state", status: http.StatusOK},
+			},
+			expectedToken: "access",
+		},
+		"redirect uri without path -- only the root is handled": {
+			redirectURI: "http://localhost:8080",
+			requests: []request{
+				{path: "/favicon.ico", status: http.StatusNotFound},
+				{path: "/?code=code&state=state", status: http.StatusOK},
+			},
+			expectedToken: "access",
+		},
+		"valid callback -- return an authorized client": {
+			requests: []request{
+				{path: "/callback?code=code&state=state", status: http.StatusOK},
+			},
+			expectedToken: "access",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			flow := PKCEFlow{
+				ClientID:    "id",
+				RedirectURI: "http://localhost:8080/callback",
+				State:       "state",
+				Verifier:    "verifier",
+			}
+			if tc.redirectURI != "" {
+				flow.RedirectURI = tc.redirectURI
+			}
+
+			timeout := tc.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("failed to listen, %s", err.Error())
+			}
+
+			httpClient := &mockHttpClient{
+				expectedResponse: mockTokenResponse(`{"access_token": "access", "refresh_token": "refresh"}`),
+			}
+			if tc.tokenResponse != nil {
+				httpClient.expectedResponse = tc.tokenResponse
+			}
+
+			// without keep alive no connection is left over, that would delay the shutdown of the server:
+			browser := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, req := range tc.requests {
+					resp, err := browser.Get("http://" + ln.Addr().String() + req.path)
+					if err != nil {
+						t.Errorf("callback request failed, %s", err.Error())
+						return
+					}
+					resp.Body.Close()
+
+					if resp.StatusCode != req.status {
+						t.Errorf("unexpected status of '%s', \n - got: '%d', \n - want: '%d'", req.path, resp.StatusCode, req.status)
+					}
+				}
+			}()
+
+			var out bytes.Buffer
+			start := time.Now()
+			c, err := serveCallback(context.Background(), ln, httpClient, flow, "user", LoginConfig{Output: &out, Timeout: timeout}, nil)
+			wg.Wait()
+			checkSpotifyError(t, tc.expectedError, err)
+
+			// a finished login must not wait for the timeout:
+			if elapsed := time.Since(start); (tc.expectedToken != "" || tc.tokenResponse != nil) && elapsed >= timeout {
+				t.Errorf("serveCallback() waited for the timeout, took '%s'", elapsed)
+			}
+
+			if got := c.Token().AccessToken; got != tc.expectedToken {
+				t.Errorf("serveCallback() token mismatch, \n - got: '%s', \n - want: '%s'", got, tc.expectedToken)
+			}
+
+			if !strings.Contains(out.String(), flow.AuthURL()) {
+				t.Errorf("serveCallback() did not print the auth url, got '%s'", out.String())
+			}
+		})
+	}
+}
+
+func TestServeCallbackTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen, %s", err.Error())
+	}
+
+	_, err = serveCallback(context.Background(), ln, &mockHttpClient{}, PKCEFlow{}, "user", LoginConfig{Output: io.Discard, Timeout: 10 * time.Millisecond}, nil)
+	checkSpotifyError(t, errSpotify{code: notAuthorized}, err)
+}
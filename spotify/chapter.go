@@ -0,0 +1,108 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// SimpleChapter is the simplified chapter object of an audiobook, as it is included e.g. in audiobooks.
+type SimpleChapter struct {
+	AudioPreviewURL  string       `json:"audio_preview_url"`
+	AvailableMarkets []string     `json:"available_markets"`
+	ChapterNumber    int          `json:"chapter_number"`
+	Description      string       `json:"description"`
+	DurationMs       int          `json:"duration_ms"`
+	Explicit         bool         `json:"explicit"`
+	ExternalURLs     ExternalURLs `json:"external_urls"`
+	Href             string       `json:"href"`
+	HTMLDescription  string       `json:"html_description"`
+	ID               string       `json:"id"`
+	Images           []Image      `json:"images"`
+	// IsPlayable and Restrictions are only set if a market was requested, see SetMarket.
+	IsPlayable           *bool         `json:"is_playable,omitempty"`
+	Restrictions         *Restrictions `json:"restrictions,omitempty"`
+	Languages            []string      `json:"languages"`
+	Name                 string        `json:"name"`
+	ReleaseDate          string        `json:"release_date"`
+	ReleaseDatePrecision string        `json:"release_date_precision"`
+	ResumePoint          *ResumePoint  `json:"resume_point,omitempty"`
+	Type                 string        `json:"type"`
+	URI                  string        `json:"uri"`
+}
+
+// Playable reports if the chapter can be played in the requested market. Chapters are assumed to
+// be playable if spotify is not telling otherwise, e.g. since no market was requested.
+func (c SimpleChapter) Playable() bool {
+	return playable(c.IsPlayable, c.Restrictions)
+}
+
+// SimpleChapterPage is a page of simplified chapters, e.g. of an audiobook.
+type SimpleChapterPage struct {
+	Href  string          `json:"href"`
+	Items []SimpleChapter `json:"items"`
+	Pagination
+}
+
+// Chapter is the full chapter object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-chapter
+type Chapter struct {
+	SimpleChapter
+	Audiobook SimpleAudiobook `json:"audiobook"`
+}
+
+// maxChaptersPerRequest is the maximum number of ids of a single request to the several chapters endpoint.
+const maxChaptersPerRequest = 50
+
+// GetChapter returns the chapter with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-chapter
+// Chapters are only available in some markets, the market is optional.
+func (c *Client) GetChapter(ctx context.Context, id spotifyuri.ID, market string) (Chapter, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Chapter{}, err
+	}
+
+	err = validateID(spotifyuri.KindChapter, id)
+	if err != nil {
+		return Chapter{}, err
+	}
+
+	var ch Chapter
+	err = c.get(ctx, endpoint("chapters", string(id))+marketQuery(market), &ch)
+	if err != nil {
+		return Chapter{}, err
+	}
+
+	return ch, nil
+}
+
+// GetChapters returns the chapters with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-several-chapters
+// The chapters are in the same order as the ids, unknown ids result in a nil chapter.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetChapters(ctx context.Context, ids []spotifyuri.ID, market string) ([]*Chapter, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Chapter, len(ids))
+	err = batch(ctx, spotifyuri.KindChapter, ids, maxChaptersPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Chapters []*Chapter `json:"chapters"`
+		}
+		err := c.get(ctx, endpoint("chapters")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Chapters)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
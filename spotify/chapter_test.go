@@ -0,0 +1,104 @@
+package spotify
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestGetChapter(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		market        string
+		defaultMarket string
+		expectedURL   string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"id of another kind -- should fail": {
+			id: "spotify:chapter:" + mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"without market": {
+			id:          mockID,
+			expectedURL: baseURL + "/chapters/" + mockID,
+		},
+		"default market": {
+			id:            mockID,
+			defaultMarket: MarketFromToken,
+			expectedURL:   baseURL + "/chapters/" + mockID + "?market=from_token",
+		},
+		"market of the call": {
+			id:            mockID,
+			market:        "SE",
+			defaultMarket: "DE",
+			expectedURL:   baseURL + "/chapters/" + mockID + "?market=SE",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: mockTokenResponse(`{"id": "` + mockID + `", "chapter_number": 3, "audiobook": {"id": "audiobook"}}`)}
+			c := mockAuthorizedClient(Client{httpClient: httpClient, market: tc.defaultMarket})
+
+			got, err := c.GetChapter(context.Background(), tc.id, tc.market)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+
+			if got.ID != mockID || got.ChapterNumber != 3 || got.Audiobook.ID != "audiobook" {
+				t.Errorf("spotify.Client.GetChapter() unexpected chapter '%+v'", got)
+			}
+		})
+	}
+}
+
+func TestGetChapters(t *testing.T) {
+	var (
+		requests []int
+		markets  []string
+	)
+	c := mockAuthorizedClient(Client{httpClient: mockMarketBatchApi(t, "chapters", &requests, &markets), market: "DE"})
+
+	ids := mockIDs(60)
+	ids[10] = mockUnknownID
+
+	got, err := c.GetChapters(context.Background(), ids, "")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetChapters() unexpected error '%s'", err.Error())
+	}
+
+	// the chunks are requested concurrently, so the order of the requests is not fixed:
+	sort.Sort(sort.Reverse(sort.IntSlice(requests)))
+
+	if diff := cmp.Diff([]int{50, 10}, requests); diff != "" {
+		t.Errorf("spotify.Client.GetChapters() chunk mismatch (-want +got):\n%s", diff)
+	}
+
+	// the default market of the client is applied to every chunk:
+	if diff := cmp.Diff([]string{"DE", "DE"}, markets); diff != "" {
+		t.Errorf("spotify.Client.GetChapters() market mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(got) != len(ids) || got[10] != nil || got[59] == nil || got[59].ID != string(ids[59]) {
+		t.Errorf("spotify.Client.GetChapters() unexpected chapters '%+v', '%+v'", got[10], got[59])
+	}
+
+	_, err = c.GetChapters(context.Background(), nil, "")
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
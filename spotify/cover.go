@@ -0,0 +1,162 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+const (
+	// maxCoverSize is the maximum size of the base64 encoded cover image accepted by spotify.
+	maxCoverSize = 256 * 1024
+	// minCoverDimension stops the downscaling of an image, before it gets useless as a cover.
+	minCoverDimension = 64
+)
+
+// coverQualities are the jpeg qualities tried in order before the image is downscaled.
+var coverQualities = []int{90, 75, 60}
+
+// GetPlaylistCover returns the cover images of a playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-playlist-cover
+func (c *Client) GetPlaylistCover(ctx context.Context, id spotifyuri.ID) ([]Image, error) {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []Image
+	err = c.get(ctx, endpoint("playlists", string(id), "images"), &images)
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// UploadPlaylistCover replaces the cover image of a playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/upload-custom-playlist-cover
+// The image is encoded as jpeg and downscaled until it fits into the size limit of spotify.
+// It requires the "ugc-image-upload" scope.
+func (c *Client) UploadPlaylistCover(ctx context.Context, id spotifyuri.ID, img image.Image) error {
+	if img == nil {
+		return newError(invalidInputs, "image is required", nil)
+	}
+
+	data, err := encodeCover(img)
+	if err != nil {
+		return err
+	}
+
+	return c.uploadCover(ctx, id, data)
+}
+
+// UploadPlaylistCoverJPEG is like UploadPlaylistCover, but takes an already encoded jpeg.
+// It is uploaded as it is if it fits into the size limit, otherwise it is re-encoded and downscaled.
+// Data that is not a jpeg, e.g. a png, is rejected before anything is uploaded.
+func (c *Client) UploadPlaylistCoverJPEG(ctx context.Context, id spotifyuri.ID, data []byte) error {
+	if len(data) == 0 {
+		return newError(invalidInputs, "image is required", nil)
+	}
+
+	_, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return newError(invalidInputs, "image is not a jpeg", err)
+	}
+
+	if base64.StdEncoding.EncodedLen(len(data)) <= maxCoverSize {
+		return c.uploadCover(ctx, id, data)
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return newError(invalidInputs, "failed to decode jpeg", err)
+	}
+
+	data, err = encodeCover(img)
+	if err != nil {
+		return err
+	}
+
+	return c.uploadCover(ctx, id, data)
+}
+
+func (c *Client) uploadCover(ctx context.Context, id spotifyuri.ID, data []byte) error {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return err
+	}
+
+	body := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(body, data)
+
+	return c.sendRaw(ctx, http.MethodPut, endpoint("playlists", string(id), "images"), "image/jpeg", body, http.StatusAccepted, nil)
+}
+
+// encodeCover encodes the image as jpeg, lowering the quality first
+// and downscaling it afterwards until it fits into maxCoverSize.
+func encodeCover(img image.Image) ([]byte, error) {
+	for {
+		for _, q := range coverQualities {
+			var buf bytes.Buffer
+			err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: q})
+			if err != nil {
+				return nil, newError(internalError, "failed to encode jpeg", err)
+			}
+
+			if base64.StdEncoding.EncodedLen(buf.Len()) <= maxCoverSize {
+				return buf.Bytes(), nil
+			}
+		}
+
+		b := img.Bounds()
+		if b.Dx()*3/4 < minCoverDimension || b.Dy()*3/4 < minCoverDimension {
+			return nil, newError(invalidInputs, "image can not be compressed to fit the cover size limit", nil)
+		}
+
+		img = downscale(img, b.Dx()*3/4, b.Dy()*3/4)
+	}
+}
+
+// downscale resizes the image to the given size by averaging the pixels
+// of the source image that are covered by each pixel of the result.
+func downscale(img image.Image, width, height int) *image.RGBA {
+	src := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := src.Min.Y + y*src.Dy()/height
+		y1 := src.Min.Y + (y+1)*src.Dy()/height
+		for x := 0; x < width; x++ {
+			x0 := src.Min.X + x*src.Dx()/width
+			x1 := src.Min.X + (x+1)*src.Dx()/width
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			if n == 0 {
+				continue
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
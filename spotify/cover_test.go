@@ -0,0 +1,184 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// mockNoiseImage is hard to compress, so it is forcing the encoder to downscale it.
+func mockNoiseImage(size int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	r := rand.New(rand.NewSource(1))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, color.RGBA{uint8(r.Intn(256)), uint8(r.Intn(256)), uint8(r.Intn(256)), 255})
+		}
+	}
+	return img
+}
+
+func TestGetPlaylistCover(t *testing.T) {
+	want := []Image{{URL: "https://mosaic.scdn.co/640/1234", Height: 640, Width: 640}}
+	httpClient := &mockHttpClient{
+		expectedResponse: createMockedHttpResponse(t, http.StatusOK, want),
+	}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+	got, err := c.GetPlaylistCover(context.Background(), mockID)
+	if err != nil {
+		t.Fatalf("spotify.Client.GetPlaylistCover() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("spotify.Client.GetPlaylistCover() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := httpClient.gotRequest.URL.String(); got != baseURL+"/playlists/"+mockID+"/images" {
+		t.Errorf("spotify.Client.GetPlaylistCover() unexpected url '%s'", got)
+	}
+}
+
+func TestUploadPlaylistCover(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		img           image.Image
+		expectedError error
+	}{
+		"id is missing -- should fail": {
+			img: mockNoiseImage(10),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"image is missing -- should fail": {
+			id: mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"small image -- upload it": {
+			id:  mockID,
+			img: mockNoiseImage(100),
+		},
+		"large image -- downscale it to fit into the limit": {
+			id:  mockID,
+			img: mockNoiseImage(1000),
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusAccepted, nil),
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.UploadPlaylistCover(context.Background(), tc.id, tc.img)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			r := httpClient.gotRequest
+			if r.Method != http.MethodPut || r.Header.Get("Content-Type") != "image/jpeg" {
+				t.Errorf("spotify.Client.UploadPlaylistCover() unexpected request '%s' with content type '%s'", r.Method, r.Header.Get("Content-Type"))
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			if len(body) > maxCoverSize {
+				t.Errorf("spotify.Client.UploadPlaylistCover() body exceeds the limit with '%d' bytes", len(body))
+			}
+
+			data, err := base64.StdEncoding.DecodeString(string(body))
+			if err != nil {
+				t.Fatalf("spotify.Client.UploadPlaylistCover() body is not base64 encoded, %s", err.Error())
+			}
+
+			if _, err := jpeg.Decode(bytes.NewReader(data)); err != nil {
+				t.Errorf("spotify.Client.UploadPlaylistCover() body is not a jpeg, %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestUploadPlaylistCoverJPEG(t *testing.T) {
+	var small bytes.Buffer
+	if err := jpeg.Encode(&small, mockNoiseImage(50), nil); err != nil {
+		t.Fatalf("failed to encode mock image, %s", err.Error())
+	}
+
+	var smallPNG bytes.Buffer
+	if err := png.Encode(&smallPNG, mockNoiseImage(50)); err != nil {
+		t.Fatalf("failed to encode mock image, %s", err.Error())
+	}
+
+	testcases := map[string]struct {
+		data          []byte
+		expectedError error
+		expectedBody  string
+	}{
+		"no data -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"too large and not a jpeg -- should fail": {
+			data: bytes.Repeat([]byte("x"), maxCoverSize),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"small png -- should fail": {
+			data: smallPNG.Bytes(),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"small and not an image -- should fail": {
+			data: []byte("mock"),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"fits into the limit -- upload it unchanged": {
+			data:         small.Bytes(),
+			expectedBody: base64.StdEncoding.EncodeToString(small.Bytes()),
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusAccepted, nil),
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.UploadPlaylistCoverJPEG(context.Background(), mockID, tc.data)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				if httpClient.gotRequest != nil {
+					t.Error("spotify.Client.UploadPlaylistCoverJPEG() uploaded invalid data")
+				}
+				return
+			}
+
+			body, _ := io.ReadAll(httpClient.gotRequest.Body)
+			if string(body) != tc.expectedBody {
+				t.Error("spotify.Client.UploadPlaylistCoverJPEG() did not upload the jpeg unchanged")
+			}
+		})
+	}
+}
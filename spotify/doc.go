@@ -0,0 +1,15 @@
+// Package spotify is a client of the spotify web api described here:
+// https://developer.spotify.com/documentation/web-api/
+//
+// # Contexts
+//
+// Every method that sends requests takes a context.Context as first argument, e.g.
+//
+//	p, err := c.GetPlaylist(ctx, id, "")
+//
+// The context bounds the call including its retries, see WithTimeout for a default timeout.
+// Authorize, GetUserPlaylists, CreatePlaylist, PKCEFlow.Exchange and LoginWithCallback are older
+// than this convention and are kept for compatibility. They are using context.Background() and
+// each of them has a variant with the suffix Context, e.g. AuthorizeContext, which takes the
+// context as first argument like every other method. New methods are only added in this form.
+package spotify
@@ -0,0 +1,109 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// ResumePoint is the position the user stopped listening to an episode.
+type ResumePoint struct {
+	FullyPlayed      bool `json:"fully_played"`
+	ResumePositionMs int  `json:"resume_position_ms"`
+}
+
+// Episode is the full episode object of a podcast described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-episode
+type Episode struct {
+	AudioPreviewURL    string       `json:"audio_preview_url"`
+	Description        string       `json:"description"`
+	DurationMs         int          `json:"duration_ms"`
+	Explicit           bool         `json:"explicit"`
+	ExternalURLs       ExternalURLs `json:"external_urls"`
+	Href               string       `json:"href"`
+	HTMLDescription    string       `json:"html_description"`
+	ID                 string       `json:"id"`
+	Images             []Image      `json:"images"`
+	IsExternallyHosted bool         `json:"is_externally_hosted"`
+	// IsPlayable and Restrictions are only set if a market was requested, see SetMarket.
+	IsPlayable           *bool         `json:"is_playable,omitempty"`
+	Restrictions         *Restrictions `json:"restrictions,omitempty"`
+	Languages            []string      `json:"languages"`
+	Name                 string        `json:"name"`
+	ReleaseDate          string        `json:"release_date"`
+	ReleaseDatePrecision string        `json:"release_date_precision"`
+	ResumePoint          *ResumePoint  `json:"resume_point,omitempty"`
+	// Show is nil for the simplified episodes, e.g. the episodes of a show.
+	Show *SimpleShow `json:"show,omitempty"`
+	Type string      `json:"type"`
+	URI  string      `json:"uri"`
+}
+
+// Playable reports if the episode can be played in the requested market. Episodes are assumed to
+// be playable if spotify is not telling otherwise, e.g. since no market was requested.
+func (e Episode) Playable() bool {
+	return playable(e.IsPlayable, e.Restrictions)
+}
+
+// EpisodePage is a page of episodes, e.g. of a search.
+type EpisodePage struct {
+	Href  string    `json:"href"`
+	Items []Episode `json:"items"`
+	Pagination
+}
+
+// maxEpisodesPerRequest is the maximum number of ids of a single request to the several episodes endpoint.
+const maxEpisodesPerRequest = 50
+
+// GetEpisode returns the episode with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-an-episode
+// The market is optional and defaults to the market of the client, see SetMarket.
+func (c *Client) GetEpisode(ctx context.Context, id spotifyuri.ID, market string) (Episode, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Episode{}, err
+	}
+
+	err = validateID(spotifyuri.KindEpisode, id)
+	if err != nil {
+		return Episode{}, err
+	}
+
+	var e Episode
+	err = c.get(ctx, endpoint("episodes", string(id))+marketQuery(market), &e)
+	if err != nil {
+		return Episode{}, err
+	}
+
+	return e, nil
+}
+
+// GetEpisodes returns the episodes with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-multiple-episodes
+// The episodes are in the same order as the ids, unknown ids result in a nil episode.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetEpisodes(ctx context.Context, ids []spotifyuri.ID, market string) ([]*Episode, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Episode, len(ids))
+	err = batch(ctx, spotifyuri.KindEpisode, ids, maxEpisodesPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Episodes []*Episode `json:"episodes"`
+		}
+		err := c.get(ctx, endpoint("episodes")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Episodes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -14,6 +14,9 @@ type errResponse struct {
 	Err struct {
 		Status  int    `json:"status"`
 		Message string `json:"message"`
+		// Reason is only sent by some endpoints, e.g. the player is sending "NO_ACTIVE_DEVICE":
+		// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-information-about-the-users-current-playback
+		Reason string `json:"reason,omitempty"`
 	} `json:"error"`
 }
 
@@ -68,6 +68,7 @@ func TestParseErrResponse(t *testing.T) {
 			resp: createMockedHttpResponse(t, http.StatusBadRequest, errResponse{Err: struct {
 				Status  int    "json:\"status\""
 				Message string "json:\"message\""
+				Reason  string "json:\"reason,omitempty\""
 			}{Status: 400}}),
 			checkError: func(t *testing.T, err error) {
 				if err == nil {
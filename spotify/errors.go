@@ -1,6 +1,10 @@
 package spotify
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 type errCode int64
 
@@ -9,14 +13,32 @@ const (
 	requestFailed
 	invalidInputs
 	internalError
+	requestCanceled
+	noActiveDevice
+	premiumRequired
 )
 
+// ErrCanceled can be used to check via errors.Is if a request failed
+// because its context was canceled or ran into its deadline.
+var ErrCanceled error = errSpotify{code: requestCanceled, msg: "request was canceled"}
+
+// ErrNoActiveDevice can be used to check via errors.Is if a player request failed,
+// because the user has no active device to play on.
+var ErrNoActiveDevice error = errSpotify{code: noActiveDevice, msg: "no active device"}
+
+// ErrPremiumRequired can be used to check via errors.Is if a player request failed,
+// because controlling the playback requires a premium account.
+var ErrPremiumRequired error = errSpotify{code: premiumRequired, msg: "premium required"}
+
 func (e errCode) String() string {
 	return [...]string{
 		"notAuthorized",
 		"requestFailed",
 		"invalidInputs",
 		"internalError",
+		"requestCanceled",
+		"noActiveDevice",
+		"premiumRequired",
 	}[e]
 }
 
@@ -57,3 +79,29 @@ func newError(code errCode, msg string, err error) errSpotify {
 		err,
 	}
 }
+
+// checkCanceled converts an error caused by a done context into an error with the requestCanceled code.
+// Any other error is returned as it is.
+func checkCanceled(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	return newError(requestCanceled, "request was canceled", err)
+}
+
+// requestError converts an error of a failed request into an errSpotify, with a distinct code
+// for the known reasons of the error responses of the api.
+func requestError(err error) error {
+	var resp errResponse
+	if errors.As(err, &resp) {
+		switch resp.Err.Reason {
+		case "NO_ACTIVE_DEVICE":
+			return newError(noActiveDevice, "no active device", err)
+		case "PREMIUM_REQUIRED":
+			return newError(premiumRequired, "premium required", err)
+		}
+	}
+
+	return newError(requestFailed, "failed to request api", err)
+}
@@ -1,6 +1,7 @@
 package spotify
 
 import (
+	"context"
 	"errors"
 	"testing"
 )
@@ -94,3 +95,40 @@ func TestUnwrap(t *testing.T) {
 		t.Errorf("errSpotify.Is(): unexpected result, \n - got: '%v', \n - want: '%v'", got, want)
 	}
 }
+
+func TestCheckCanceled(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testcases := map[string]struct {
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		"no error -- nothing to convert": {
+			ctx: canceled,
+		},
+		"context is not done -- keep the error": {
+			ctx: context.Background(),
+			err: errMock,
+		},
+		"context is done -- convert to a canceled error": {
+			ctx:  canceled,
+			err:  context.Canceled,
+			want: true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got := checkCanceled(tc.ctx, tc.err)
+			if errors.Is(got, ErrCanceled) != tc.want {
+				t.Errorf("checkCanceled() mismatch, got: '%v'", got)
+			}
+
+			if tc.err != nil && !errors.Is(got, tc.err) {
+				t.Errorf("checkCanceled() lost the original error, got: '%v'", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// FollowType is the type of the objects the current user can follow, besides playlists.
+type FollowType string
+
+const (
+	FollowTypeArtist FollowType = "artist"
+	FollowTypeUser   FollowType = "user"
+)
+
+// kind returns the kind of the ids of the objects of the follow type.
+func (t FollowType) kind() (spotifyuri.Kind, error) {
+	switch t {
+	case FollowTypeArtist:
+		return spotifyuri.KindArtist, nil
+	case FollowTypeUser:
+		return spotifyuri.KindUser, nil
+	}
+
+	return "", newError(invalidInputs, "unknown follow type '"+string(t)+"'", nil)
+}
+
+// maxFollowsPerRequest is the maximum number of ids of a single request to the follow endpoints.
+const maxFollowsPerRequest = 50
+
+// followURL returns the url of the follow endpoints for the ids of the given type.
+func followURL(t FollowType, ids []spotifyuri.ID, segments ...string) string {
+	q := url.Values{
+		"type": {string(t)},
+		"ids":  {joinIDs(ids)},
+	}
+
+	return endpoint(append([]string{"me", "following"}, segments...)...) + "?" + q.Encode()
+}
+
+func (c *Client) follow(ctx context.Context, method string, t FollowType, ids []spotifyuri.ID) error {
+	kind, err := t.kind()
+	if err != nil {
+		return err
+	}
+
+	return batch(ctx, kind, ids, maxFollowsPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		return c.send(ctx, method, followURL(t, chunk), nil, http.StatusNoContent, nil)
+	})
+}
+
+// Follow lets the current user follow the artists or users as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/follow-artists-users
+// It requires the user-follow-modify scope.
+func (c *Client) Follow(ctx context.Context, t FollowType, ids []spotifyuri.ID) error {
+	return c.follow(ctx, http.MethodPut, t, ids)
+}
+
+// Unfollow lets the current user unfollow the artists or users as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/unfollow-artists-users
+// It requires the user-follow-modify scope.
+func (c *Client) Unfollow(ctx context.Context, t FollowType, ids []spotifyuri.ID) error {
+	return c.follow(ctx, http.MethodDelete, t, ids)
+}
+
+// FollowingContains checks if the current user follows the artists or users as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-current-user-follows
+// The results are in the same order as the ids. It requires the user-follow-read scope.
+func (c *Client) FollowingContains(ctx context.Context, t FollowType, ids []spotifyuri.ID) ([]bool, error) {
+	kind, err := t.kind()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]bool, len(ids))
+	err = batch(ctx, kind, ids, maxFollowsPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp []bool
+		err := c.get(ctx, followURL(t, chunk, "contains"), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// FollowedArtistsPage is a cursor based page of the artists the user follows.
+type FollowedArtistsPage struct {
+	Href  string   `json:"href"`
+	Items []Artist `json:"items"`
+	CursorPagination
+}
+
+// FollowedArtistsOptions are the optional parameters of GetFollowedArtists.
+type FollowedArtistsOptions struct {
+	// Limit is the page size, up to 50.
+	Limit int
+	// After is the id of the last artist of the previous page, see Cursors.
+	After string
+}
+
+func followedArtistsURL(opts FollowedArtistsOptions) (string, error) {
+	if opts.Limit < 0 {
+		return "", newError(invalidInputs, "limit can not be negative", nil)
+	}
+
+	q := url.Values{"type": {string(FollowTypeArtist)}}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.After != "" {
+		q.Set("after", opts.After)
+	}
+
+	return endpoint("me", "following") + "?" + q.Encode(), nil
+}
+
+// GetFollowedArtists returns a page of the artists the current user follows as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-followed
+// The page is cursor based, the next page can be requested with the after cursor of the page or
+// with PaginateFollowedArtists. It requires the user-follow-read scope.
+func (c *Client) GetFollowedArtists(ctx context.Context, opts FollowedArtistsOptions) (FollowedArtistsPage, error) {
+	u, err := followedArtistsURL(opts)
+	if err != nil {
+		return FollowedArtistsPage{}, err
+	}
+
+	var resp struct {
+		Artists FollowedArtistsPage `json:"artists"`
+	}
+	err = c.get(ctx, u, &resp)
+	if err != nil {
+		return FollowedArtistsPage{}, err
+	}
+
+	return resp.Artists, nil
+}
+
+// PaginateFollowedArtists returns a paginator over all artists the current user follows,
+// the pages are of type FollowedArtistsPage.
+func (c *Client) PaginateFollowedArtists(ctx context.Context, opts FollowedArtistsOptions) *Paginator {
+	u, err := followedArtistsURL(opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
@@ -0,0 +1,142 @@
+package spotify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestFollow(t *testing.T) {
+	testcases := map[string]struct {
+		followType    FollowType
+		ids           []spotifyuri.ID
+		expectedURL   string
+		expectedError error
+	}{
+		"unknown type -- should fail": {
+			followType: "playlist",
+			ids:        []spotifyuri.ID{mockID},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no ids -- should fail": {
+			followType: FollowTypeArtist,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"follow users": {
+			followType:  FollowTypeUser,
+			ids:         []spotifyuri.ID{"a", "b"},
+			expectedURL: baseURL + "/me/following?ids=a%2Cb&type=user",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.Follow(context.Background(), tc.followType, tc.ids)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if httpClient.gotRequest.Method != http.MethodPut || httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected request '%s %s', want '%s'", httpClient.gotRequest.Method, httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestFollowingContains(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/v1/me/following/contains" || r.URL.Query().Get("type") != "artist" {
+			t.Errorf("unexpected url '%s'", r.URL.String())
+		}
+
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		resp := make([]bool, len(ids))
+		for i, id := range ids {
+			resp[i] = id == mockID
+		}
+		return createMockedHttpResponse(t, http.StatusOK, resp), nil
+	})})
+
+	got, err := c.FollowingContains(context.Background(), FollowTypeArtist, []spotifyuri.ID{mockID, mockIDs(1)[0], mockID})
+	if err != nil {
+		t.Fatalf("spotify.Client.FollowingContains() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff([]bool{true, false, true}, got); diff != "" {
+		t.Errorf("spotify.Client.FollowingContains() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPaginateFollowedArtists(t *testing.T) {
+	var urls []string
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		urls = append(urls, r.URL.String())
+
+		after := r.URL.Query().Get("after")
+		page := map[string]interface{}{
+			"items":   []Artist{{ID: "after-" + after}},
+			"cursors": Cursors{After: "1"},
+			"limit":   1,
+			"total":   2,
+		}
+		if after == "" {
+			page["next"] = baseURL + "/me/following?after=1&limit=1&type=artist"
+		}
+
+		return createMockedHttpResponse(t, http.StatusOK, map[string]interface{}{"artists": page}), nil
+	})})
+
+	var got []Artist
+	err := c.PaginateFollowedArtists(context.Background(), FollowedArtistsOptions{Limit: 1}).Collect(&got)
+	if err != nil {
+		t.Fatalf("spotify.Client.PaginateFollowedArtists() unexpected error '%s'", err.Error())
+	}
+
+	wantURLs := []string{
+		baseURL + "/me/following?limit=1&type=artist",
+		baseURL + "/me/following?after=1&limit=1&type=artist",
+	}
+	if diff := cmp.Diff(wantURLs, urls); diff != "" {
+		t.Errorf("spotify.Client.PaginateFollowedArtists() url mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(got) != 2 || got[0].ID != "after-" || got[1].ID != "after-1" {
+		t.Errorf("spotify.Client.PaginateFollowedArtists() unexpected artists '%+v'", got)
+	}
+}
+
+func TestFollowPlaylist(t *testing.T) {
+	httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, nil)}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+	err := c.FollowPlaylist(context.Background(), "", true)
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+
+	err = c.FollowPlaylist(context.Background(), mockID, false)
+	if err != nil {
+		t.Fatalf("spotify.Client.FollowPlaylist() unexpected error '%s'", err.Error())
+	}
+
+	if want := baseURL + "/playlists/" + mockID + "/followers"; httpClient.gotRequest.Method != http.MethodPut || httpClient.gotRequest.URL.String() != want {
+		t.Errorf("unexpected request '%s %s', want '%s'", httpClient.gotRequest.Method, httpClient.gotRequest.URL.String(), want)
+	}
+
+	body, _ := io.ReadAll(httpClient.gotRequest.Body)
+	if string(body) != `{"public":false}` {
+		t.Errorf("unexpected body '%s'", string(body))
+	}
+}
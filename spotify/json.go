@@ -0,0 +1,91 @@
+package spotify
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// ExtraFields holds the fields of a response object that are not (yet) part of our models.
+// This keeps them available for the caller if spotify is adding new fields to its api.
+type ExtraFields map[string]json.RawMessage
+
+// unmarshalWithExtra decodes data into v, which has to be a pointer to a struct, and returns
+// all fields of the json object that are not mapped to a field of the struct.
+func unmarshalWithExtra(data []byte, v interface{}) (ExtraFields, error) {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	var extra ExtraFields
+	for k, raw := range fields {
+		if known[k] {
+			continue
+		}
+
+		if extra == nil {
+			extra = ExtraFields{}
+		}
+		extra[k] = raw
+	}
+
+	return extra, nil
+}
+
+// marshalWithExtra encodes v and adds the extra fields to the resulting json object.
+// Fields of v always win over extra fields with the same name.
+func marshalWithExtra(v interface{}, extra ExtraFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, raw := range extra {
+		if _, ok := fields[k]; !ok {
+			fields[k] = raw
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonFieldNames returns the json names of all fields of the struct type t, including embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n := range jsonFieldNames(f.Type) {
+				names[n] = true
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+
+	return names
+}
@@ -0,0 +1,560 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// TimeRange is the time frame the top items of a user are calculated for.
+type TimeRange string
+
+const (
+	// TimeRangeShort is about the last four weeks.
+	TimeRangeShort TimeRange = "short_term"
+	// TimeRangeMedium is about the last six months, it is the default of spotify.
+	TimeRangeMedium TimeRange = "medium_term"
+	// TimeRangeLong is calculated from several years of data.
+	TimeRangeLong TimeRange = "long_term"
+)
+
+// TopItemsOptions are the optional parameters of GetTopArtists and GetTopTracks.
+type TopItemsOptions struct {
+	TimeRange TimeRange
+	PageOptions
+}
+
+func topItemsURL(kind string, opts TopItemsOptions) (string, error) {
+	switch opts.TimeRange {
+	case "", TimeRangeShort, TimeRangeMedium, TimeRangeLong:
+	default:
+		return "", newError(invalidInputs, "unknown time range '"+string(opts.TimeRange)+"'", nil)
+	}
+
+	err := opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	if opts.TimeRange != "" {
+		q.Set("time_range", string(opts.TimeRange))
+	}
+	opts.apply(q)
+
+	u := endpoint("me", "top", kind)
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u, nil
+}
+
+// GetTopArtists returns a page of the top artists of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-top-artists-and-tracks
+// It requires the user-top-read scope.
+func (c *Client) GetTopArtists(ctx context.Context, opts TopItemsOptions) (ArtistPage, error) {
+	u, err := topItemsURL("artists", opts)
+	if err != nil {
+		return ArtistPage{}, err
+	}
+
+	var page ArtistPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return ArtistPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateTopArtists returns a paginator over the top artists of the current user, the pages are of type ArtistPage.
+func (c *Client) PaginateTopArtists(ctx context.Context, opts TopItemsOptions) *Paginator {
+	u, err := topItemsURL("artists", opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// GetTopTracks returns a page of the top tracks of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-top-artists-and-tracks
+// It requires the user-top-read scope.
+func (c *Client) GetTopTracks(ctx context.Context, opts TopItemsOptions) (TrackPage, error) {
+	u, err := topItemsURL("tracks", opts)
+	if err != nil {
+		return TrackPage{}, err
+	}
+
+	var page TrackPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return TrackPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateTopTracks returns a paginator over the top tracks of the current user, the pages are of type TrackPage.
+func (c *Client) PaginateTopTracks(ctx context.Context, opts TopItemsOptions) *Paginator {
+	u, err := topItemsURL("tracks", opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// PlayHistory is a track the user has played.
+type PlayHistory struct {
+	Track    Track     `json:"track"`
+	PlayedAt time.Time `json:"played_at"`
+	// Context is nil if the track was not played from e.g. a playlist or an album.
+	Context *PlaybackContext `json:"context"`
+}
+
+// RecentlyPlayedPage is a cursor based page of the recently played tracks.
+type RecentlyPlayedPage struct {
+	Href  string        `json:"href"`
+	Items []PlayHistory `json:"items"`
+	CursorPagination
+}
+
+// RecentlyPlayedOptions are the optional parameters of GetRecentlyPlayed.
+// Only one of Before and After can be set.
+type RecentlyPlayedOptions struct {
+	// Limit is the page size, up to 50.
+	Limit int
+	// Before only returns tracks played before this time.
+	Before time.Time
+	// After only returns tracks played after this time.
+	After time.Time
+}
+
+func recentlyPlayedURL(opts RecentlyPlayedOptions) (string, error) {
+	if opts.Limit < 0 {
+		return "", newError(invalidInputs, "limit can not be negative", nil)
+	}
+
+	if !opts.Before.IsZero() && !opts.After.IsZero() {
+		return "", newError(invalidInputs, "only one of before and after can be set", nil)
+	}
+
+	q := url.Values{}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if !opts.Before.IsZero() {
+		q.Set("before", strconv.FormatInt(opts.Before.UnixMilli(), 10))
+	}
+	if !opts.After.IsZero() {
+		q.Set("after", strconv.FormatInt(opts.After.UnixMilli(), 10))
+	}
+
+	u := endpoint("me", "player", "recently-played")
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u, nil
+}
+
+// GetRecentlyPlayed returns a page of the tracks the current user played recently as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-recently-played
+// It requires the user-read-recently-played scope. The page is cursor based, the next page can be
+// requested with the cursors of the page or with PaginateRecentlyPlayed.
+func (c *Client) GetRecentlyPlayed(ctx context.Context, opts RecentlyPlayedOptions) (RecentlyPlayedPage, error) {
+	u, err := recentlyPlayedURL(opts)
+	if err != nil {
+		return RecentlyPlayedPage{}, err
+	}
+
+	var page RecentlyPlayedPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return RecentlyPlayedPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateRecentlyPlayed returns a paginator over the recently played tracks, the pages are of type RecentlyPlayedPage.
+func (c *Client) PaginateRecentlyPlayed(ctx context.Context, opts RecentlyPlayedOptions) *Paginator {
+	u, err := recentlyPlayedURL(opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SavedTrack is a track in the library of the user.
+type SavedTrack struct {
+	AddedAt time.Time `json:"added_at"`
+	Track   Track     `json:"track"`
+}
+
+// SavedTrackPage is a page of the saved tracks of the user.
+type SavedTrackPage struct {
+	Href  string       `json:"href"`
+	Items []SavedTrack `json:"items"`
+	Pagination
+}
+
+// SavedAlbum is an album in the library of the user.
+type SavedAlbum struct {
+	AddedAt time.Time `json:"added_at"`
+	Album   Album     `json:"album"`
+}
+
+// SavedAlbumPage is a page of the saved albums of the user.
+type SavedAlbumPage struct {
+	Href  string       `json:"href"`
+	Items []SavedAlbum `json:"items"`
+	Pagination
+}
+
+// libraryPath returns the path segment of the library endpoints of the given kind, e.g. "tracks".
+func libraryPath(kind spotifyuri.Kind) string {
+	return string(kind) + "s"
+}
+
+func savedItemsURL(kind spotifyuri.Kind, market string, opts PageOptions) (string, error) {
+	return pagedURL(endpoint("me", libraryPath(kind)), market, opts)
+}
+
+// saveItems adds or removes the items with the given ids to or from the library of the user,
+// depending on the method. The ids are split into chunks of the given size and sent as query,
+// since some of the endpoints, e.g. the shows, are not accepting them as body.
+func (c *Client) saveItems(ctx context.Context, method string, kind spotifyuri.Kind, ids []spotifyuri.ID, size int) error {
+	return batch(ctx, kind, ids, size, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		return c.send(ctx, method, endpoint("me", libraryPath(kind))+idsQuery(chunk, ""), nil, http.StatusOK, nil)
+	})
+}
+
+// containsItems checks if the items with the given ids are in the library of the user.
+// The results are in the same order as the ids.
+func (c *Client) containsItems(ctx context.Context, kind spotifyuri.Kind, ids []spotifyuri.ID, size int) ([]bool, error) {
+	out := make([]bool, len(ids))
+	err := batch(ctx, kind, ids, size, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp []bool
+		err := c.get(ctx, endpoint("me", libraryPath(kind), "contains")+idsQuery(chunk, ""), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetSavedTracks returns a page of the saved tracks of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-saved-tracks
+// It requires the user-library-read scope.
+func (c *Client) GetSavedTracks(ctx context.Context, market string, opts PageOptions) (SavedTrackPage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return SavedTrackPage{}, err
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindTrack, market, opts)
+	if err != nil {
+		return SavedTrackPage{}, err
+	}
+
+	var page SavedTrackPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SavedTrackPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateSavedTracks returns a paginator over the saved tracks of the current user, the pages are of type SavedTrackPage.
+func (c *Client) PaginateSavedTracks(ctx context.Context, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindTrack, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SaveTracks adds the tracks to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/save-tracks-user
+// It requires the user-library-modify scope.
+func (c *Client) SaveTracks(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodPut, spotifyuri.KindTrack, ids, maxTracksPerRequest)
+}
+
+// RemoveSavedTracks removes the tracks from the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-tracks-user
+// It requires the user-library-modify scope.
+func (c *Client) RemoveSavedTracks(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodDelete, spotifyuri.KindTrack, ids, maxTracksPerRequest)
+}
+
+// SavedTracksContain checks if the tracks are saved in the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-users-saved-tracks
+// The results are in the same order as the ids.
+func (c *Client) SavedTracksContain(ctx context.Context, ids []spotifyuri.ID) ([]bool, error) {
+	return c.containsItems(ctx, spotifyuri.KindTrack, ids, maxTracksPerRequest)
+}
+
+// GetSavedAlbums returns a page of the saved albums of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-saved-albums
+// It requires the user-library-read scope.
+func (c *Client) GetSavedAlbums(ctx context.Context, market string, opts PageOptions) (SavedAlbumPage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return SavedAlbumPage{}, err
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindAlbum, market, opts)
+	if err != nil {
+		return SavedAlbumPage{}, err
+	}
+
+	var page SavedAlbumPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SavedAlbumPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateSavedAlbums returns a paginator over the saved albums of the current user, the pages are of type SavedAlbumPage.
+func (c *Client) PaginateSavedAlbums(ctx context.Context, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindAlbum, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SaveAlbums adds the albums to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/save-albums-user
+// It requires the user-library-modify scope.
+func (c *Client) SaveAlbums(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodPut, spotifyuri.KindAlbum, ids, maxAlbumsPerRequest)
+}
+
+// RemoveSavedAlbums removes the albums from the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-albums-user
+// It requires the user-library-modify scope.
+func (c *Client) RemoveSavedAlbums(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodDelete, spotifyuri.KindAlbum, ids, maxAlbumsPerRequest)
+}
+
+// SavedAlbumsContain checks if the albums are saved in the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-users-saved-albums
+// The results are in the same order as the ids.
+func (c *Client) SavedAlbumsContain(ctx context.Context, ids []spotifyuri.ID) ([]bool, error) {
+	return c.containsItems(ctx, spotifyuri.KindAlbum, ids, maxAlbumsPerRequest)
+}
+
+// SavedShow is a show the user follows.
+type SavedShow struct {
+	AddedAt time.Time  `json:"added_at"`
+	Show    SimpleShow `json:"show"`
+}
+
+// SavedShowPage is a page of the saved shows of the user.
+type SavedShowPage struct {
+	Href  string      `json:"href"`
+	Items []SavedShow `json:"items"`
+	Pagination
+}
+
+// SavedEpisode is an episode in the library of the user.
+type SavedEpisode struct {
+	AddedAt time.Time `json:"added_at"`
+	Episode Episode   `json:"episode"`
+}
+
+// SavedEpisodePage is a page of the saved episodes of the user.
+type SavedEpisodePage struct {
+	Href  string         `json:"href"`
+	Items []SavedEpisode `json:"items"`
+	Pagination
+}
+
+// GetSavedShows returns a page of the saved shows of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-saved-shows
+// It requires the user-library-read scope.
+func (c *Client) GetSavedShows(ctx context.Context, opts PageOptions) (SavedShowPage, error) {
+	u, err := savedItemsURL(spotifyuri.KindShow, "", opts)
+	if err != nil {
+		return SavedShowPage{}, err
+	}
+
+	var page SavedShowPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SavedShowPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateSavedShows returns a paginator over the saved shows of the current user, the pages are of type SavedShowPage.
+func (c *Client) PaginateSavedShows(ctx context.Context, opts PageOptions) *Paginator {
+	u, err := savedItemsURL(spotifyuri.KindShow, "", opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SaveShows adds the shows to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/save-shows-user
+// It requires the user-library-modify scope.
+func (c *Client) SaveShows(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodPut, spotifyuri.KindShow, ids, maxShowsPerRequest)
+}
+
+// RemoveSavedShows removes the shows from the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-shows-user
+// It requires the user-library-modify scope.
+func (c *Client) RemoveSavedShows(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodDelete, spotifyuri.KindShow, ids, maxShowsPerRequest)
+}
+
+// SavedShowsContain checks if the shows are saved in the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-users-saved-shows
+// The results are in the same order as the ids.
+func (c *Client) SavedShowsContain(ctx context.Context, ids []spotifyuri.ID) ([]bool, error) {
+	return c.containsItems(ctx, spotifyuri.KindShow, ids, maxShowsPerRequest)
+}
+
+// GetSavedEpisodes returns a page of the saved episodes of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-saved-episodes
+// It requires the user-library-read scope.
+func (c *Client) GetSavedEpisodes(ctx context.Context, market string, opts PageOptions) (SavedEpisodePage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return SavedEpisodePage{}, err
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindEpisode, market, opts)
+	if err != nil {
+		return SavedEpisodePage{}, err
+	}
+
+	var page SavedEpisodePage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SavedEpisodePage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateSavedEpisodes returns a paginator over the saved episodes of the current user, the pages are of type SavedEpisodePage.
+func (c *Client) PaginateSavedEpisodes(ctx context.Context, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := savedItemsURL(spotifyuri.KindEpisode, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SaveEpisodes adds the episodes to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/save-episodes-user
+// It requires the user-library-modify scope.
+func (c *Client) SaveEpisodes(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodPut, spotifyuri.KindEpisode, ids, maxEpisodesPerRequest)
+}
+
+// RemoveSavedEpisodes removes the episodes from the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-episodes-user
+// It requires the user-library-modify scope.
+func (c *Client) RemoveSavedEpisodes(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodDelete, spotifyuri.KindEpisode, ids, maxEpisodesPerRequest)
+}
+
+// SavedEpisodesContain checks if the episodes are saved in the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-users-saved-episodes
+// The results are in the same order as the ids.
+func (c *Client) SavedEpisodesContain(ctx context.Context, ids []spotifyuri.ID) ([]bool, error) {
+	return c.containsItems(ctx, spotifyuri.KindEpisode, ids, maxEpisodesPerRequest)
+}
+
+// GetSavedAudiobooks returns a page of the saved audiobooks of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-saved-audiobooks
+// It requires the user-library-read scope.
+func (c *Client) GetSavedAudiobooks(ctx context.Context, opts PageOptions) (SimpleAudiobookPage, error) {
+	u, err := savedItemsURL(spotifyuri.KindAudiobook, "", opts)
+	if err != nil {
+		return SimpleAudiobookPage{}, err
+	}
+
+	var page SimpleAudiobookPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return SimpleAudiobookPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateSavedAudiobooks returns a paginator over the saved audiobooks of the current user, the pages are of type SimpleAudiobookPage.
+func (c *Client) PaginateSavedAudiobooks(ctx context.Context, opts PageOptions) *Paginator {
+	u, err := savedItemsURL(spotifyuri.KindAudiobook, "", opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
+
+// SaveAudiobooks adds the audiobooks to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/save-audiobooks-user
+// It requires the user-library-modify scope.
+func (c *Client) SaveAudiobooks(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodPut, spotifyuri.KindAudiobook, ids, maxAudiobooksPerRequest)
+}
+
+// RemoveSavedAudiobooks removes the audiobooks from the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-audiobooks-user
+// It requires the user-library-modify scope.
+func (c *Client) RemoveSavedAudiobooks(ctx context.Context, ids []spotifyuri.ID) error {
+	return c.saveItems(ctx, http.MethodDelete, spotifyuri.KindAudiobook, ids, maxAudiobooksPerRequest)
+}
+
+// SavedAudiobooksContain checks if the audiobooks are saved in the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-users-saved-audiobooks
+// The results are in the same order as the ids.
+func (c *Client) SavedAudiobooksContain(ctx context.Context, ids []spotifyuri.ID) ([]bool, error) {
+	return c.containsItems(ctx, spotifyuri.KindAudiobook, ids, maxAudiobooksPerRequest)
+}
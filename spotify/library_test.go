@@ -0,0 +1,159 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetTopTracks(t *testing.T) {
+	testcases := map[string]struct {
+		opts          TopItemsOptions
+		expectedURL   string
+		expectedError error
+	}{
+		"unknown time range -- should fail": {
+			opts: TopItemsOptions{TimeRange: "mock"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"negative limit -- should fail": {
+			opts: TopItemsOptions{PageOptions: PageOptions{Limit: -1}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no options": {
+			expectedURL: baseURL + "/me/top/tracks",
+		},
+		"time range and page": {
+			opts:        TopItemsOptions{TimeRange: TimeRangeShort, PageOptions: PageOptions{Limit: 50}},
+			expectedURL: baseURL + "/me/top/tracks?limit=50&time_range=short_term",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, TrackPage{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			_, err := c.GetTopTracks(context.Background(), tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if tc.expectedURL != "" && httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestPaginateRecentlyPlayed(t *testing.T) {
+	before := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	playedAt := before.Add(-time.Hour)
+
+	var urls []string
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		urls = append(urls, r.URL.String())
+
+		// the second page is the last one:
+		page := map[string]interface{}{
+			"items":   []PlayHistory{{Track: Track{ID: r.URL.Query().Get("before")}, PlayedAt: playedAt}},
+			"cursors": Cursors{Before: "2"},
+			"limit":   1,
+		}
+		if r.URL.Query().Get("before") != "2" {
+			page["next"] = baseURL + "/me/player/recently-played?before=2&limit=1"
+		}
+
+		return createMockedHttpResponse(t, http.StatusOK, page), nil
+	})})
+
+	var got []PlayHistory
+	err := c.PaginateRecentlyPlayed(context.Background(), RecentlyPlayedOptions{Limit: 1, Before: before}).Collect(&got)
+	if err != nil {
+		t.Fatalf("spotify.Client.PaginateRecentlyPlayed() unexpected error '%s'", err.Error())
+	}
+
+	wantURLs := []string{
+		baseURL + "/me/player/recently-played?before=1659355200000&limit=1",
+		baseURL + "/me/player/recently-played?before=2&limit=1",
+	}
+	if diff := cmp.Diff(wantURLs, urls); diff != "" {
+		t.Errorf("spotify.Client.PaginateRecentlyPlayed() url mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(got) != 2 || got[1].Track.ID != "2" || !got[1].PlayedAt.Equal(playedAt) {
+		t.Errorf("spotify.Client.PaginateRecentlyPlayed() unexpected items '%+v'", got)
+	}
+}
+
+func TestRecentlyPlayedInvalidInputs(t *testing.T) {
+	now := time.Now()
+	c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{}})
+
+	_, err := c.GetRecentlyPlayed(context.Background(), RecentlyPlayedOptions{Before: now, After: now})
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
+
+func TestSaveTracks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		chunks [][]string
+	)
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v1/me/tracks" {
+			t.Errorf("unexpected request '%s %s'", r.Method, r.URL.String())
+		}
+
+		mu.Lock()
+		chunks = append(chunks, strings.Split(r.URL.Query().Get("ids"), ","))
+		mu.Unlock()
+
+		return createMockedHttpResponse(t, http.StatusOK, nil), nil
+	})})
+
+	err := c.SaveTracks(context.Background(), mockIDs(60))
+	if err != nil {
+		t.Fatalf("spotify.Client.SaveTracks() unexpected error '%s'", err.Error())
+	}
+
+	saved := 0
+	for _, chunk := range chunks {
+		if len(chunk) > maxTracksPerRequest {
+			t.Errorf("spotify.Client.SaveTracks() saved '%d' tracks at once", len(chunk))
+		}
+		saved += len(chunk)
+	}
+
+	if len(chunks) != 2 || saved != 60 {
+		t.Errorf("spotify.Client.SaveTracks() expected 60 tracks in 2 requests, got '%d' in '%d'", saved, len(chunks))
+	}
+}
+
+func TestSavedAlbumsContain(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		resp := make([]bool, len(ids))
+		for i, id := range ids {
+			resp[i] = strings.HasSuffix(id, "0")
+		}
+		return createMockedHttpResponse(t, http.StatusOK, resp), nil
+	})})
+
+	got, err := c.SavedAlbumsContain(context.Background(), mockIDs(25))
+	if err != nil {
+		t.Fatalf("spotify.Client.SavedAlbumsContain() unexpected error '%s'", err.Error())
+	}
+
+	for i, v := range got {
+		if want := i%10 == 0; v != want {
+			t.Errorf("spotify.Client.SavedAlbumsContain() unexpected result '%t' for '%d'", v, i)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package spotify
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MarketFromToken can be used as market to let spotify take the country of the user the token
+// belongs to. It is not working with the client credentials flow, since its tokens have no user.
+const MarketFromToken = "from_token"
+
+var (
+	marketPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	localePattern = regexp.MustCompile(`^[a-z]{2}(_[A-Z]{2})?$`)
+)
+
+// SetMarket sets the default market of the client, an ISO 3166-1 alpha-2 country code like "DE"
+// or MarketFromToken. Every call that is taking a market is using it, unless the call has its own.
+// Relinked tracks are only returned and the playability of tracks is only known with a market.
+// An empty market removes the default.
+func (c *Client) SetMarket(market string) error {
+	err := validateMarket(market)
+	if err != nil {
+		return err
+	}
+
+	c.market = market
+	return nil
+}
+
+// SetLocale sets the default locale of the client, an ISO 639-1 language code optionally joined with
+// an ISO 3166-1 alpha-2 country code by an underscore, e.g. "es_MX". It is sent as Accept-Language
+// header with every request and used by the browse endpoints, unless the call has its own.
+// An empty locale removes the default.
+func (c *Client) SetLocale(locale string) error {
+	err := validateLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	c.locale = locale
+	return nil
+}
+
+// validateLocale checks that the locale is empty or like "es" or "es_MX".
+func validateLocale(locale string) error {
+	if locale != "" && !localePattern.MatchString(locale) {
+		return newError(invalidInputs, "locale has to be like 'es' or 'es_MX', got '"+locale+"'", nil)
+	}
+
+	return nil
+}
+
+// validateCountry checks that the country of the browse endpoints is empty or an ISO 3166-1 alpha-2
+// country code. In contrast to the market, MarketFromToken is not supported as country.
+func validateCountry(country string) error {
+	if country != "" && !marketPattern.MatchString(country) {
+		return newError(invalidInputs, "country has to be an ISO 3166-1 alpha-2 country code, got '"+country+"'", nil)
+	}
+
+	return nil
+}
+
+// validateMarket checks that the market is empty, an ISO 3166-1 alpha-2 country code or MarketFromToken.
+func validateMarket(market string) error {
+	if market != "" && market != MarketFromToken && !marketPattern.MatchString(market) {
+		return newError(invalidInputs, "market has to be an ISO 3166-1 alpha-2 country code or '"+MarketFromToken+"', got '"+market+"'", nil)
+	}
+
+	return nil
+}
+
+// marketOr returns the market of a call or the default market of the client if it is empty.
+// The market of a call is validated like the one of SetMarket.
+func (c *Client) marketOr(market string) (string, error) {
+	if market == "" {
+		return c.market, nil
+	}
+
+	err := validateMarket(market)
+	if err != nil {
+		return "", err
+	}
+
+	return market, nil
+}
+
+// acceptLanguage returns the default locale as value of the Accept-Language header, e.g. "es-MX".
+func (c *Client) acceptLanguage() string {
+	return strings.ReplaceAll(c.locale, "_", "-")
+}
+
+// browseDefaults fills the country and locale of the browse options with the defaults of the client.
+// The country of the browse endpoints is not supporting MarketFromToken, so it is not used then.
+func (c *Client) browseDefaults(opts BrowseOptions) BrowseOptions {
+	if opts.Country == "" && c.market != MarketFromToken {
+		opts.Country = c.market
+	}
+
+	if opts.Locale == "" {
+		opts.Locale = c.locale
+	}
+
+	return opts
+}
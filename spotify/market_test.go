@@ -0,0 +1,253 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestSetMarket(t *testing.T) {
+	testcases := map[string]struct {
+		market        string
+		expectedError error
+	}{
+		"lower case country -- should fail": {
+			market: "de",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"country": {
+			market: "DE",
+		},
+		"from token": {
+			market: MarketFromToken,
+		},
+		"empty removes the default": {},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			c := Client{market: "SE"}
+			err := c.SetMarket(tc.market)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if err == nil && c.market != tc.market {
+				t.Errorf("spotify.Client.SetMarket() market mismatch, \n - got: '%s', \n - want: '%s'", c.market, tc.market)
+			}
+		})
+	}
+}
+
+func TestDefaultMarket(t *testing.T) {
+	testcases := map[string]struct {
+		defaultMarket string
+		locale        string
+		call          func(c *Client) error
+		expectedURL   string
+		expectedLang  string
+	}{
+		"no default": {
+			call: func(c *Client) error {
+				_, err := c.GetTrack(context.Background(), mockID, "")
+				return err
+			},
+			expectedURL: baseURL + "/tracks/" + mockID,
+		},
+		"default market": {
+			defaultMarket: "DE",
+			call: func(c *Client) error {
+				_, err := c.GetTrack(context.Background(), mockID, "")
+				return err
+			},
+			expectedURL: baseURL + "/tracks/" + mockID + "?market=DE",
+		},
+		"per call override": {
+			defaultMarket: "DE",
+			call: func(c *Client) error {
+				_, err := c.GetTrack(context.Background(), mockID, MarketFromToken)
+				return err
+			},
+			expectedURL: baseURL + "/tracks/" + mockID + "?market=from_token",
+		},
+		"playlist with its own market": {
+			defaultMarket: "DE",
+			call: func(c *Client) error {
+				_, err := c.GetPlaylist(context.Background(), mockID, "SE")
+				return err
+			},
+			expectedURL: baseURL + "/playlists/" + mockID + "?market=SE",
+		},
+		"options override": {
+			defaultMarket: "DE",
+			call: func(c *Client) error {
+				_, err := c.Search(context.Background(), "mock", []SearchType{SearchTypeTrack}, SearchOptions{Market: "SE"})
+				return err
+			},
+			expectedURL: baseURL + "/search?market=SE&q=mock&type=track",
+		},
+		"top tracks with the default market": {
+			defaultMarket: MarketFromToken,
+			call: func(c *Client) error {
+				_, err := c.GetArtistTopTracks(context.Background(), mockID, "")
+				return err
+			},
+			expectedURL: baseURL + "/artists/" + mockID + "/top-tracks?market=from_token",
+		},
+		"browse with default country and locale": {
+			defaultMarket: "MX",
+			locale:        "es_MX",
+			call: func(c *Client) error {
+				_, err := c.GetCategories(context.Background(), BrowseOptions{})
+				return err
+			},
+			expectedURL:  baseURL + "/browse/categories?country=MX&locale=es_MX",
+			expectedLang: "es-MX",
+		},
+		"browse is not sending from token as country": {
+			defaultMarket: MarketFromToken,
+			call: func(c *Client) error {
+				_, err := c.GetNewReleases(context.Background(), BrowseOptions{})
+				return err
+			},
+			expectedURL: baseURL + "/browse/new-releases",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, struct{}{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+			if err := c.SetMarket(tc.defaultMarket); err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+			if err := c.SetLocale(tc.locale); err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			err := tc.call(&c)
+			if err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			if httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+
+			if got := httpClient.gotRequest.Header.Get("Accept-Language"); got != tc.expectedLang {
+				t.Errorf("unexpected Accept-Language header, \n - got: '%s', \n - want: '%s'", got, tc.expectedLang)
+			}
+		})
+	}
+}
+
+func TestInvalidCallMarket(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{}, market: "DE"})
+	ctx := context.Background()
+
+	_, trackErr := c.GetTrack(ctx, mockID, "de")
+	_, playlistErr := c.GetPlaylist(ctx, mockID, "Germany")
+	_, searchErr := c.Search(ctx, "mock", []SearchType{SearchTypeTrack}, SearchOptions{Market: "de"})
+	_, recommendationsErr := c.GetRecommendations(ctx, NewRecommendationsQuery().SeedGenres("rock").Market("de"))
+
+	for name, err := range map[string]error{
+		"GetTrack":            trackErr,
+		"GetPlaylist":         playlistErr,
+		"Search":              searchErr,
+		"PaginateSavedTracks": c.PaginateSavedTracks(ctx, "de", PageOptions{}).Err(),
+		"GetRecommendations":  recommendationsErr,
+	} {
+		if !errors.Is(err, errSpotify{code: invalidInputs}) {
+			t.Errorf("spotify.Client.%s() expected an invalid inputs error, got '%v'", name, err)
+		}
+	}
+}
+
+func TestTrackPlayable(t *testing.T) {
+	testcases := map[string]struct {
+		data string
+		want bool
+	}{
+		"no market requested": {
+			data: `{"id": "track"}`,
+			want: true,
+		},
+		"playable": {
+			data: `{"id": "track", "is_playable": true}`,
+			want: true,
+		},
+		"relinked": {
+			data: `{"id": "track", "is_playable": true, "linked_from": {"id": "original"}}`,
+			want: true,
+		},
+		"restricted": {
+			data: `{"id": "track", "is_playable": false, "restrictions": {"reason": "market"}}`,
+			want: false,
+		},
+		"restricted without playable flag": {
+			data: `{"id": "track", "restrictions": {"reason": "explicit"}}`,
+			want: false,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var track Track
+			if err := json.Unmarshal([]byte(tc.data), &track); err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			if got := track.Playable(); got != tc.want {
+				t.Errorf("spotify.Track.Playable() mismatch, got '%t', want '%t'", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEpisodeAndChapterPlayable(t *testing.T) {
+	testcases := map[string]struct {
+		data string
+		want bool
+	}{
+		"no market requested": {
+			data: `{"id": "episode"}`,
+			want: true,
+		},
+		"playable": {
+			data: `{"id": "episode", "is_playable": true}`,
+			want: true,
+		},
+		"not playable": {
+			data: `{"id": "episode", "is_playable": false}`,
+			want: false,
+		},
+		"restricted without playable flag": {
+			data: `{"id": "episode", "restrictions": {"reason": "market"}}`,
+			want: false,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var episode Episode
+			if err := json.Unmarshal([]byte(tc.data), &episode); err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			if got := episode.Playable(); got != tc.want {
+				t.Errorf("spotify.Episode.Playable() mismatch, got '%t', want '%t'", got, tc.want)
+			}
+
+			var chapter Chapter
+			if err := json.Unmarshal([]byte(tc.data), &chapter); err != nil {
+				t.Fatalf("unexpected error '%s'", err.Error())
+			}
+
+			if got := chapter.Playable(); got != tc.want {
+				t.Errorf("spotify.Chapter.Playable() mismatch, got '%t', want '%t'", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package spotify
+
+// This file holds the objects that are shared between many responses of the spotify web api,
+// they are described here: https://developer.spotify.com/documentation/web-api/reference/#/
+
+// ExternalURLs are the known external urls of an object, for now spotify is only sending its own.
+type ExternalURLs struct {
+	Spotify string `json:"spotify"`
+}
+
+// Image is the representation of a cover art or profile image. The size is 0 if it is unknown.
+type Image struct {
+	URL    string `json:"url"`
+	Height int    `json:"height"`
+	Width  int    `json:"width"`
+}
+
+// Followers is the information about the followers of a user, artist or playlist.
+// Href is always empty, since spotify is not supporting it for now.
+type Followers struct {
+	Href  string `json:"href"`
+	Total int64  `json:"total"`
+}
+
+// SimpleUser is the public information of a user as it is included in other objects,
+// e.g. the owner of a playlist.
+type SimpleUser struct {
+	DisplayName  string       `json:"display_name"`
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Followers    Followers    `json:"followers"`
+	Href         string       `json:"href"`
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
+
+// Copyright is the copyright statement of an album, show or audiobook.
+// Type is "C" for the copyright and "P" for the sound recording (performance) copyright.
+type Copyright struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// PlaybackContext is the context a track was played in, e.g. a playlist or an album.
+type PlaybackContext struct {
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Href         string       `json:"href"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
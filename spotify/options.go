@@ -0,0 +1,250 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a client created by New.
+type ClientOption func(o *clientOptions) error
+
+// clientOptions is the client under construction and the default headers,
+// which are only known after all options are applied.
+type clientOptions struct {
+	client Client
+	header http.Header
+}
+
+// New creates a client configured by the given options, e.g. to talk to a local mock
+// or to go through a proxy:
+//
+//	c, err := spotify.New(
+//		spotify.WithCredentials(id, secret),
+//		spotify.WithAPIBaseURL("http://localhost:8080/v1"),
+//		spotify.WithUserAgent("spotify-playlists/1.0"),
+//		spotify.WithTimeout(10*time.Second),
+//	)
+//
+// Without options it is the same as an unauthorized NewClient, the options are validated
+// and the first invalid one is returned as error.
+func New(opts ...ClientOption) (Client, error) {
+	o := clientOptions{
+		client: Client{
+			httpClient: http.DefaultClient,
+			auth:       newTokenSource(Token{}),
+			retry:      DefaultRetryPolicy,
+		},
+		header: http.Header{},
+	}
+
+	for _, opt := range opts {
+		err := opt(&o)
+		if err != nil {
+			return Client{}, err
+		}
+	}
+
+	c := o.client
+	if len(o.header) > 0 {
+		c.httpClient = headerClient{next: c.httpClient, header: o.header}
+	}
+
+	return c, nil
+}
+
+// WithCredentials sets the id and secret of the app for the client credentials flow, see Authorize.
+func WithCredentials(id, secret string) ClientOption {
+	return func(o *clientOptions) error {
+		if id == "" || secret == "" {
+			return newError(invalidInputs, "client id and secret are required", nil)
+		}
+
+		o.client.id = id
+		o.client.secret = secret
+		return nil
+	}
+}
+
+// WithAccessToken sets an existing access token, like NewAuthorizedClient.
+func WithAccessToken(token string) ClientOption {
+	return func(o *clientOptions) error {
+		if token == "" {
+			return newError(invalidInputs, "access token can not be empty", nil)
+		}
+
+		o.client.auth = newTokenSource(Token{AccessToken: token})
+		return nil
+	}
+}
+
+// WithUser sets the user the client is acting for, otherwise the user of the token is looked up.
+func WithUser(user string) ClientOption {
+	return func(o *clientOptions) error {
+		o.client.userName = user
+		return nil
+	}
+}
+
+// WithAPIBaseURL replaces "https://api.spotify.com/v1" as base of every api request,
+// including the next urls of pages returned by spotify.
+func WithAPIBaseURL(u string) ClientOption {
+	return func(o *clientOptions) error {
+		base, err := parseBaseURL(u)
+		if err != nil {
+			return err
+		}
+
+		o.client.apiBaseURL = base
+		return nil
+	}
+}
+
+// WithAccountsBaseURL replaces "https://accounts.spotify.com" as base of the token requests.
+// Use PKCEFlow.AccountsURL for the login of a user.
+func WithAccountsBaseURL(u string) ClientOption {
+	return func(o *clientOptions) error {
+		base, err := parseBaseURL(u)
+		if err != nil {
+			return err
+		}
+
+		o.client.accountsBaseURL = base
+		return nil
+	}
+}
+
+// WithHttpClient sets the client every request is sent with, it defaults to http.DefaultClient.
+func WithHttpClient(httpClient HttpClient) ClientOption {
+	return func(o *clientOptions) error {
+		if httpClient == nil {
+			return newError(invalidInputs, "http client can not be nil", nil)
+		}
+
+		o.client.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithTimeout limits the duration of every call, including its retries. A shorter deadline
+// of the context of a call still wins. A timed out call fails with ErrCanceled.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) error {
+		if d <= 0 {
+			return newError(invalidInputs, "timeout has to be positive", nil)
+		}
+
+		o.client.timeout = d
+		return nil
+	}
+}
+
+// headerToken is the set of characters a header name is allowed to consist of.
+var headerToken = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// WithHeader adds a header that is sent with every request, e.g. for an egress gateway.
+// The Authorization header is managed by the client and can not be set.
+func WithHeader(key, value string) ClientOption {
+	return func(o *clientOptions) error {
+		if !headerToken.MatchString(key) {
+			return newError(invalidInputs, "invalid header name '"+key+"'", nil)
+		}
+
+		if http.CanonicalHeaderKey(key) == "Authorization" {
+			return newError(invalidInputs, "the authorization header can not be overwritten", nil)
+		}
+
+		if value == "" || strings.ContainsAny(value, "\r\n\x00") {
+			return newError(invalidInputs, "invalid value of header '"+key+"'", nil)
+		}
+
+		o.header.Add(key, value)
+		return nil
+	}
+}
+
+// parseBaseURL validates an absolute http(s) url and removes a trailing slash.
+func parseBaseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", newError(invalidInputs, "invalid base url '"+raw+"'", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", newError(invalidInputs, "base url has to be an absolute http or https url, got '"+raw+"'", nil)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", newError(invalidInputs, "base url can not contain credentials, a query or a fragment, got '"+raw+"'", nil)
+	}
+
+	return strings.TrimSuffix(u.String(), "/"), nil
+}
+
+// resolve points an url of the api to the configured base url. The urls are built with
+// the default base url, and the next urls of pages are pointing to spotify as well.
+func (c *Client) resolve(u string) string {
+	if c.apiBaseURL == "" || !strings.HasPrefix(u, baseURL) {
+		return u
+	}
+
+	return c.apiBaseURL + strings.TrimPrefix(u, baseURL)
+}
+
+// isAPIURL reports if the url is pointing to the api, either to spotify or to the configured base url.
+// The token of the client is only sent to urls of the api.
+func (c *Client) isAPIURL(u string) bool {
+	for _, base := range []string{baseURL, c.apiBaseURL} {
+		if base == "" {
+			continue
+		}
+
+		if u == base || strings.HasPrefix(u, base+"/") || strings.HasPrefix(u, base+"?") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenURL returns the token endpoint of the given accounts service, or of spotify if it is empty.
+func tokenURL(accountsBaseURL string) string {
+	if accountsBaseURL == "" {
+		return baseTokenURL
+	}
+
+	return accountsBaseURL + "/api/token"
+}
+
+// withTimeout applies the default timeout of the client to the context of a call.
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// headerClient adds the default headers to every request, that does not set them itself.
+type headerClient struct {
+	next   HttpClient
+	header http.Header
+}
+
+func (h headerClient) Do(req *http.Request) (*http.Response, error) {
+	for key, values := range h.header {
+		if req.Header.Get(key) == "" {
+			req.Header[key] = append([]string(nil), values...)
+		}
+	}
+
+	return h.next.Do(req)
+}
@@ -0,0 +1,181 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewInvalidOptions(t *testing.T) {
+	testcases := map[string]ClientOption{
+		"missing secret":           WithCredentials("id", ""),
+		"empty token":              WithAccessToken(""),
+		"relative base url":        WithAPIBaseURL("/v1"),
+		"unknown scheme":           WithAPIBaseURL("ftp://localhost/v1"),
+		"base url with query":      WithAccountsBaseURL("http://localhost?a=b"),
+		"nil http client":          WithHttpClient(nil),
+		"empty user agent":         WithUserAgent(""),
+		"negative timeout":         WithTimeout(-time.Second),
+		"invalid header name":      WithHeader("X Gateway", "1"),
+		"header value with break":  WithHeader("X-Gateway", "1\r\nX-Other: 2"),
+		"authorization header set": WithHeader("authorization", "Bearer 1"),
+	}
+
+	for testName, opt := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := New(WithAccessToken("token"), opt)
+			checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+		})
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	var got []string
+	httpClient := mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		got = append(got, r.Method+" "+r.URL.String())
+
+		if ua := r.UserAgent(); ua != "test/1.0" {
+			t.Errorf("unexpected user agent '%s'", ua)
+		}
+		if v := r.Header.Get("X-Gateway"); v != "abc" {
+			t.Errorf("unexpected gateway header '%s'", v)
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/api/token") {
+			return mockTokenResponse(`{"access_token": "token"}`), nil
+		}
+		return createMockedHttpResponse(t, http.StatusOK, Track{ID: "1"}), nil
+	})
+
+	c, err := New(
+		WithCredentials("id", "secret"),
+		WithUser("user"),
+		WithAPIBaseURL("http://localhost:8080/v1/"),
+		WithAccountsBaseURL("http://localhost:8081"),
+		WithHttpClient(httpClient),
+		WithUserAgent("test/1.0"),
+		WithHeader("X-Gateway", "abc"),
+	)
+	if err != nil {
+		t.Fatalf("spotify.New() unexpected error '%s'", err.Error())
+	}
+
+	err = c.Authorize()
+	if err != nil {
+		t.Fatalf("spotify.Client.Authorize() unexpected error '%s'", err.Error())
+	}
+
+	_, err = c.GetTrack(context.Background(), mockID, "")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetTrack() unexpected error '%s'", err.Error())
+	}
+
+	// next urls of pages are pointing to spotify and have to be resolved as well:
+	err = c.get(context.Background(), baseURL+"/tracks/"+mockID+"?offset=20", nil)
+	if err != nil {
+		t.Fatalf("spotify.Client.get() unexpected error '%s'", err.Error())
+	}
+
+	want := []string{
+		"POST http://localhost:8081/api/token",
+		"GET http://localhost:8080/v1/tracks/" + mockID,
+		"GET http://localhost:8080/v1/tracks/" + mockID + "?offset=20",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests, \n - got: '%v', \n - want: '%v'", got, want)
+	}
+}
+
+func TestNewWithTimeout(t *testing.T) {
+	c, err := New(
+		WithAccessToken("token"),
+		WithTimeout(10*time.Millisecond),
+		WithHttpClient(mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		})),
+	)
+	if err != nil {
+		t.Fatalf("spotify.New() unexpected error '%s'", err.Error())
+	}
+
+	_, err = c.GetTrack(context.Background(), mockID, "")
+	if !errors.Is(err, ErrCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("spotify.Client.GetTrack() expected a canceled error, got '%v'", err)
+	}
+}
+
+func TestPKCEAccountsURL(t *testing.T) {
+	var gotURL string
+	httpClient := mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		gotURL = r.URL.String()
+		return mockTokenResponse(`{"access_token": "token"}`), nil
+	})
+
+	flow := PKCEFlow{ClientID: "id", RedirectURI: "http://localhost/callback", AccountsURL: "http://localhost:8081/"}
+	if u := flow.AuthURL(); !strings.HasPrefix(u, "http://localhost:8081/authorize?") {
+		t.Errorf("spotify.PKCEFlow.AuthURL() unexpected url '%s'", u)
+	}
+
+	c, err := flow.Exchange(httpClient, "code", "user")
+	if err != nil {
+		t.Fatalf("spotify.PKCEFlow.Exchange() unexpected error '%s'", err.Error())
+	}
+
+	if gotURL != "http://localhost:8081/api/token" || c.accountsBaseURL != "http://localhost:8081" {
+		t.Errorf("spotify.PKCEFlow.Exchange() did not use the accounts url, got '%s'", gotURL)
+	}
+
+	flow.AccountsURL = "localhost:8081"
+	_, err = flow.Exchange(httpClient, "code", "user")
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
+
+func TestPKCEExchangeWithOptions(t *testing.T) {
+	var got []string
+	httpClient := mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		got = append(got, r.Method+" "+r.URL.String())
+
+		if v := r.Header.Get("X-Gateway"); v != "abc" {
+			t.Errorf("unexpected gateway header '%s'", v)
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/api/token") {
+			return mockTokenResponse(`{"access_token": "token", "refresh_token": "refresh"}`), nil
+		}
+		return createMockedHttpResponse(t, http.StatusOK, Track{ID: mockID}), nil
+	})
+
+	flow := PKCEFlow{ClientID: "id", RedirectURI: "http://localhost/callback"}
+	c, err := flow.Exchange(httpClient, "code", "user",
+		WithAPIBaseURL("http://localhost:8080/v1"),
+		WithAccountsBaseURL("http://localhost:8081"),
+		WithHeader("X-Gateway", "abc"),
+	)
+	if err != nil {
+		t.Fatalf("spotify.PKCEFlow.Exchange() unexpected error '%s'", err.Error())
+	}
+
+	_, err = c.GetTrack(context.Background(), mockID, "")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetTrack() unexpected error '%s'", err.Error())
+	}
+
+	want := []string{
+		"POST http://localhost:8081/api/token",
+		"GET http://localhost:8080/v1/tracks/" + mockID,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests, \n - got: '%v', \n - want: '%v'", got, want)
+	}
+
+	if c.id != "id" || c.userName != "user" {
+		t.Errorf("spotify.PKCEFlow.Exchange() unexpected client id '%s' or user '%s'", c.id, c.userName)
+	}
+
+	_, err = flow.Exchange(httpClient, "code", "user", WithTimeout(0))
+	checkSpotifyError(t, errSpotify{code: invalidInputs}, err)
+}
@@ -0,0 +1,285 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// PageOptions are the common query parameters of every paged endpoint.
+// Zero values are not sent, so spotify is using its defaults.
+type PageOptions struct {
+	// Limit is the page size, most endpoints are allowing up to 50 items per page.
+	Limit int
+	// Offset is the index of the first item to return.
+	Offset int
+}
+
+func (o PageOptions) validate() error {
+	if o.Limit < 0 || o.Offset < 0 {
+		return newError(invalidInputs, "limit and offset can not be negative", nil)
+	}
+
+	return nil
+}
+
+func (o PageOptions) apply(q url.Values) {
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+}
+
+// pagedURL returns the url of a paged endpoint with the optional market and the page options.
+func pagedURL(u, market string, opts PageOptions) (string, error) {
+	err := opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	if market != "" {
+		q.Set("market", market)
+	}
+	opts.apply(q)
+
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u, nil
+}
+
+// Paginator is iterating over all pages of a paged endpoint, similar to a bufio.Scanner:
+//
+//	p := c.PaginateUserPlaylists(ctx, spotify.PageOptions{Limit: 50})
+//	var page spotify.UserPlaylists
+//	for p.Next(&page) {
+//		// handle page.Items
+//	}
+//	if err := p.Err(); err != nil {
+//		// handle the error
+//	}
+//
+// It is following the next urls of the pages and falls back to the offsets,
+// in case an endpoint is not sending a next url.
+type Paginator struct {
+	c    *Client
+	ctx  context.Context
+	next string
+	err  error
+}
+
+// newPaginator creates a paginator starting at the given url of a paged endpoint.
+func (c *Client) newPaginator(ctx context.Context, rawURL string, opts PageOptions) *Paginator {
+	p := &Paginator{c: c, ctx: ctx}
+
+	err := opts.validate()
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		p.err = newError(internalError, "failed to parse url", err)
+		return p
+	}
+
+	q := u.Query()
+	opts.apply(q)
+	u.RawQuery = q.Encode()
+	p.next = u.String()
+
+	return p
+}
+
+// Next fetches the next page and decodes it into page, which has to be a pointer
+// to the page type of the endpoint, e.g. *UserPlaylists. It returns false once all
+// pages are fetched, the context is done or an error occurred, see Err.
+func (p *Paginator) Next(page interface{}) bool {
+	raw, ok := p.nextPage()
+	if !ok {
+		return false
+	}
+
+	v := reflect.ValueOf(page)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		p.err = newError(invalidInputs, "page has to be a non nil pointer", nil)
+		return false
+	}
+
+	// reset the page first, otherwise fields of the previous page could survive:
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+
+	err := json.Unmarshal(raw, page)
+	if err != nil {
+		p.err = newError(internalError, "failed to decode page", err)
+		return false
+	}
+
+	return true
+}
+
+// Collect fetches all remaining pages and appends their items to dst,
+// which has to be a pointer to a slice of the item type, e.g. *[]SimplePlaylist.
+func (p *Paginator) Collect(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return newError(invalidInputs, "destination has to be a pointer to a slice", nil)
+	}
+
+	items := v.Elem()
+	for {
+		raw, ok := p.nextPage()
+		if !ok {
+			return p.Err()
+		}
+
+		var page struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		err := json.Unmarshal(raw, &page)
+		if err != nil {
+			p.err = newError(internalError, "failed to decode page", err)
+			return p.err
+		}
+
+		for _, item := range page.Items {
+			elem := reflect.New(items.Type().Elem())
+			err := json.Unmarshal(item, elem.Interface())
+			if err != nil {
+				p.err = newError(internalError, "failed to decode item", err)
+				return p.err
+			}
+			items.Set(reflect.Append(items, elem.Elem()))
+		}
+	}
+}
+
+// Err returns the first error that occurred while paginating.
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+func (p *Paginator) nextPage() (json.RawMessage, bool) {
+	if p.err != nil || p.next == "" {
+		return nil, false
+	}
+
+	if err := p.ctx.Err(); err != nil {
+		p.err = checkCanceled(p.ctx, err)
+		return nil, false
+	}
+
+	current := p.next
+
+	var body json.RawMessage
+	err := p.c.get(p.ctx, current, &body)
+	if err != nil {
+		p.err = err
+		return nil, false
+	}
+
+	raw, err := unwrapPage(body)
+	if err != nil {
+		p.err = newError(internalError, "failed to decode page", err)
+		return nil, false
+	}
+
+	var info struct {
+		Pagination
+		// the offset is a pointer, since only offset based pages are allowed
+		// to fall back to the offset to find the next page:
+		Offset *int64 `json:"offset"`
+	}
+	err = json.Unmarshal(raw, &info)
+	if err != nil {
+		p.err = newError(internalError, "failed to decode page", err)
+		return nil, false
+	}
+
+	// the next url is taken from the response, so the token must not be sent anywhere else than to the api:
+	if info.Next != "" && !p.c.isAPIURL(info.Next) {
+		p.err = newError(requestFailed, "next url '"+info.Next+"' is not pointing to the api", nil)
+		return raw, true
+	}
+
+	p.next = info.Next
+	if p.next == "" && info.Offset != nil {
+		p.next = nextOffsetURL(current, *info.Offset, info.Limit, info.Total)
+	}
+
+	// never request the same page twice, e.g. if an endpoint is ignoring the offset:
+	if p.next == current {
+		p.next = ""
+	}
+
+	return raw, true
+}
+
+// nextOffsetURL builds the url of the following page based on the offset of the current one.
+func nextOffsetURL(current string, offset, limit, total int64) string {
+	if limit <= 0 || offset+limit >= total {
+		return ""
+	}
+
+	u, err := url.Parse(current)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	q.Set("offset", strconv.FormatInt(offset+limit, 10))
+	q.Set("limit", strconv.FormatInt(limit, 10))
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+// unwrapPage returns the actual paging object of a response. Some endpoints like the search
+// are wrapping the paging object into another object, e.g. {"tracks": {"items": [...]}}.
+func unwrapPage(body json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := fields["items"]; ok {
+		return body, nil
+	}
+
+	for _, v := range fields {
+		var inner map[string]json.RawMessage
+		if json.Unmarshal(v, &inner) != nil {
+			continue
+		}
+
+		if _, ok := inner["items"]; ok {
+			return v, nil
+		}
+	}
+
+	return body, nil
+}
+
+// Cursors are the positions of a cursor based page, e.g. the recently played tracks.
+type Cursors struct {
+	After  string `json:"after"`
+	Before string `json:"before"`
+}
+
+// CursorPagination is the representation of the pagination values of endpoints that
+// are paging with cursors instead of offsets. The Paginator is following their next urls as well.
+type CursorPagination struct {
+	Cursors Cursors `json:"cursors"`
+	Limit   int64   `json:"limit"`
+	Next    string  `json:"next"`
+	Total   int64   `json:"total"`
+}
@@ -0,0 +1,254 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// mockPagedApi is answering requests with pages of the items "0" to "<total-1>",
+// based on the offset and limit of the request.
+func mockPagedApi(t *testing.T, total int, withNext bool, envelope string) mockHttpClientFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, err := strconv.Atoi(q.Get("limit"))
+		if err != nil {
+			limit = 20
+		}
+
+		page := map[string]interface{}{
+			"offset": offset,
+			"limit":  limit,
+			"total":  total,
+		}
+
+		items := []string{}
+		for i := offset; i < offset+limit && i < total; i++ {
+			items = append(items, strconv.Itoa(i))
+		}
+		page["items"] = items
+
+		if withNext && offset+limit < total {
+			next := *r.URL
+			nq := next.Query()
+			nq.Set("offset", strconv.Itoa(offset+limit))
+			next.RawQuery = nq.Encode()
+			page["next"] = next.String()
+		}
+
+		if envelope != "" {
+			return createMockedHttpResponse(t, http.StatusOK, map[string]interface{}{envelope: page}), nil
+		}
+
+		return createMockedHttpResponse(t, http.StatusOK, page), nil
+	}
+}
+
+func wantItems(from, to int) []string {
+	out := []string{}
+	for i := from; i < to; i++ {
+		out = append(out, strconv.Itoa(i))
+	}
+	return out
+}
+
+func TestPaginatorCollect(t *testing.T) {
+	testcases := map[string]struct {
+		httpClient    HttpClient
+		opts          PageOptions
+		want          []string
+		expectedError error
+	}{
+		"negative limit -- should fail": {
+			httpClient: mockPagedApi(t, 5, true, ""),
+			opts:       PageOptions{Limit: -1},
+			want:       []string{},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"follows the next urls": {
+			httpClient: mockPagedApi(t, 5, true, ""),
+			opts:       PageOptions{Limit: 2},
+			want:       wantItems(0, 5),
+		},
+		"falls back to the offsets": {
+			httpClient: mockPagedApi(t, 5, false, ""),
+			opts:       PageOptions{Limit: 2},
+			want:       wantItems(0, 5),
+		},
+		"starts at the given offset": {
+			httpClient: mockPagedApi(t, 5, true, ""),
+			opts:       PageOptions{Limit: 2, Offset: 3},
+			want:       wantItems(3, 5),
+		},
+		"unwraps paging objects": {
+			httpClient: mockPagedApi(t, 5, true, "tracks"),
+			opts:       PageOptions{Limit: 3},
+			want:       wantItems(0, 5),
+		},
+		"request failed -- return the error": {
+			httpClient: &mockHttpClient{expectedError: errMock},
+			want:       []string{},
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			c := mockAuthorizedClient(Client{httpClient: tc.httpClient})
+
+			got := []string{}
+			err := c.newPaginator(context.Background(), baseURL+"/mock", tc.opts).Collect(&got)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("spotify.Paginator.Collect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPaginatorNext(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockPagedApi(t, 5, true, "")})
+	p := c.newPaginator(context.Background(), baseURL+"/mock", PageOptions{Limit: 2})
+
+	var page struct {
+		Items []string `json:"items"`
+		Pagination
+	}
+
+	var got []string
+	pages := 0
+	for p.Next(&page) {
+		pages++
+		got = append(got, page.Items...)
+	}
+
+	if p.Err() != nil {
+		t.Fatalf("spotify.Paginator.Next() unexpected error '%s'", p.Err().Error())
+	}
+
+	if pages != 3 {
+		t.Errorf("spotify.Paginator.Next() expected 3 pages, got '%d'", pages)
+	}
+
+	if diff := cmp.Diff(wantItems(0, 5), got); diff != "" {
+		t.Errorf("spotify.Paginator.Next() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPaginatorForeignNextURL(t *testing.T) {
+	testcases := map[string]struct {
+		apiBaseURL    string
+		next          string
+		expectedCalls int
+		expectedError error
+	}{
+		"other host -- should fail": {
+			next:          "https://api.example.com/v1/mock?offset=1",
+			expectedCalls: 1,
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"host with the api as prefix -- should fail": {
+			next:          baseURL + ".example.com/mock?offset=1",
+			expectedCalls: 1,
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"spotify with a configured base url": {
+			apiBaseURL:    "http://localhost:8080/v1",
+			next:          baseURL + "/mock?offset=1",
+			expectedCalls: 2,
+		},
+		"configured base url": {
+			apiBaseURL:    "http://localhost:8080/v1",
+			next:          "http://localhost:8080/v1/mock?offset=1",
+			expectedCalls: 2,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var calls []string
+			c := mockAuthorizedClient(Client{
+				apiBaseURL: tc.apiBaseURL,
+				httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+					calls = append(calls, r.URL.String())
+
+					page := map[string]interface{}{"items": []string{"0"}, "limit": 1, "total": 2}
+					if len(calls) == 1 {
+						page["next"] = tc.next
+					}
+					return createMockedHttpResponse(t, http.StatusOK, page), nil
+				}),
+			})
+
+			got := []string{}
+			err := c.newPaginator(context.Background(), baseURL+"/mock", PageOptions{Limit: 1}).Collect(&got)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if len(calls) != tc.expectedCalls {
+				t.Errorf("spotify.Paginator.Collect() expected '%d' requests, got '%v'", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestPaginatorCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	api := mockPagedApi(t, 10, true, "")
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		// cancel after the first page was delivered:
+		cancel()
+		return api(r)
+	})})
+
+	p := c.newPaginator(ctx, baseURL+"/mock", PageOptions{Limit: 2})
+	var page struct {
+		Items []string `json:"items"`
+	}
+	for p.Next(&page) {
+	}
+
+	if !errors.Is(p.Err(), ErrCanceled) {
+		t.Errorf("spotify.Paginator.Next() expected a canceled error, got '%v'", p.Err())
+	}
+
+	if calls != 1 {
+		t.Errorf("spotify.Paginator.Next() expected to stop after the first page, got '%d' calls", calls)
+	}
+}
+
+func TestPaginateUserPlaylists(t *testing.T) {
+	c := mockAuthorizedClient(Client{
+		httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			want := fmt.Sprintf("%s/users/user/playlists?limit=50", baseURL)
+			if r.URL.String() != want {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", r.URL.String(), want)
+			}
+			return createMockedHttpResponse(t, http.StatusOK, UserPlaylists{Href: "test"}), nil
+		}),
+		userName: "user",
+	})
+
+	var page UserPlaylists
+	p := c.PaginateUserPlaylists(context.Background(), PageOptions{Limit: 50})
+	if !p.Next(&page) || page.Href != "test" {
+		t.Errorf("spotify.Client.PaginateUserPlaylists() unexpected first page '%+v', err: '%v'", page, p.Err())
+	}
+}
@@ -0,0 +1,203 @@
+package spotify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/url"
+	"strings"
+)
+
+const (
+	baseAuthorizeURL = baseAccountsURL + "/authorize"
+
+	// verifierBytes results in a code verifier of 64 characters after encoding,
+	// the docs are asking for a length between 43 and 128 characters.
+	verifierBytes = 48
+	stateBytes    = 16
+)
+
+// PKCEFlow holds everything needed for a single login via the
+// "Authorization Code Flow with Proof Key for Code Exchange (PKCE)" described here:
+// https://developer.spotify.com/documentation/general/guides/authorization/code-pkce-flow/
+// In contrast to the client credentials flow it grants access to user related resources,
+// e.g. the playlists of a user. State and Verifier are generated per flow, so a flow
+// should not be reused for multiple logins.
+type PKCEFlow struct {
+	ClientID    string
+	RedirectURI string
+	Scopes      []string
+	State       string
+	Verifier    string
+	// AccountsURL is the base url of the accounts service, e.g. of a mock,
+	// it defaults to "https://accounts.spotify.com". It has to be an absolute http or https url,
+	// otherwise Exchange and LoginWithCallback are failing. See WithAccountsBaseURL.
+	AccountsURL string
+}
+
+// NewPKCEFlow prepares a new login flow for the given app and scopes,
+// the available scopes are listed here:
+// https://developer.spotify.com/documentation/general/guides/authorization/scopes/
+func NewPKCEFlow(id, redirectURI string, scopes ...string) (PKCEFlow, error) {
+	if id == "" {
+		return PKCEFlow{}, newError(invalidInputs, "client id is required", nil)
+	}
+
+	if redirectURI == "" {
+		return PKCEFlow{}, newError(invalidInputs, "redirect uri is required", nil)
+	}
+
+	verifier, err := randomString(verifierBytes)
+	if err != nil {
+		return PKCEFlow{}, newError(internalError, "failed to generate code verifier", err)
+	}
+
+	state, err := randomString(stateBytes)
+	if err != nil {
+		return PKCEFlow{}, newError(internalError, "failed to generate state", err)
+	}
+
+	return PKCEFlow{
+		ClientID:    id,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+		State:       state,
+		Verifier:    verifier,
+	}, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// accountsBaseURL returns the validated AccountsURL, it is empty for spotify.
+func (f PKCEFlow) accountsBaseURL() (string, error) {
+	if f.AccountsURL == "" {
+		return "", nil
+	}
+
+	return parseBaseURL(f.AccountsURL)
+}
+
+// codeChallenge is the base64 url encoded sha256 hash of the verifier.
+func codeChallenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// AuthURL returns the url the user has to open to grant access to the app.
+// Spotify is redirecting afterwards to the RedirectURI with a "code" and the "state".
+func (f PKCEFlow) AuthURL() string {
+	v := url.Values{
+		"client_id":             {f.ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {f.RedirectURI},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {codeChallenge(f.Verifier)},
+		"state":                 {f.State},
+	}
+
+	if len(f.Scopes) > 0 {
+		v.Set("scope", strings.Join(f.Scopes, " "))
+	}
+
+	u := baseAuthorizeURL
+	if f.AccountsURL != "" {
+		u = strings.TrimSuffix(f.AccountsURL, "/") + "/authorize"
+	}
+
+	return u + "?" + v.Encode()
+}
+
+// ValidateState checks the state that was sent back by spotify against the one of the flow,
+// to make sure the redirect belongs to this login attempt.
+func (f PKCEFlow) ValidateState(state string) error {
+	if f.State == "" || subtle.ConstantTimeCompare([]byte(f.State), []byte(state)) != 1 {
+		return newError(invalidInputs, "state mismatch", nil)
+	}
+
+	return nil
+}
+
+// Exchange trades the code that was sent to the RedirectURI for an access and a refresh token
+// and returns a client that is authorized to act on behalf of the given user.
+// The user can be empty, the client is looking up the user the token belongs to then.
+// The client is configured by the given options like New, e.g. to talk to a mock:
+//
+//	c, err := flow.Exchange(http.DefaultClient, code, "", spotify.WithAPIBaseURL("http://localhost:8080/v1"))
+//
+// The token request is already sent with the headers, timeout and accounts url of the options.
+// The client id of the flow and the exchanged token are replacing the ones of the options.
+func (f PKCEFlow) Exchange(httpClient HttpClient, code, user string, opts ...ClientOption) (Client, error) {
+	return f.ExchangeContext(context.Background(), httpClient, code, user, opts...)
+}
+
+// ExchangeContext is like Exchange, but the token request is bound to the given context.
+func (f PKCEFlow) ExchangeContext(ctx context.Context, httpClient HttpClient, code, user string, opts ...ClientOption) (Client, error) {
+	if code == "" {
+		return Client{}, newError(invalidInputs, "authorization code is required", nil)
+	}
+
+	c, err := f.newClient(httpClient, user, opts)
+	if err != nil {
+		return Client{}, err
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req, err := createTokenRequest(ctx, tokenURL(c.accountsBaseURL), url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {f.RedirectURI},
+		"client_id":     {f.ClientID},
+		"code_verifier": {f.Verifier},
+	}, "")
+	if err != nil {
+		return Client{}, newError(internalError, "failed to create token request", err)
+	}
+
+	body, err := requestToken(c.httpClient, req)
+	if err != nil {
+		return Client{}, newError(notAuthorized, "failed to exchange authorization code", err)
+	}
+
+	if body.AccessToken == "" {
+		return Client{}, newError(notAuthorized, "token response did not contain an access token", nil)
+	}
+
+	c.auth = newTokenSource(body.token())
+	return c, nil
+}
+
+// newClient creates the client of the flow, the given options are applied after the ones of the flow.
+func (f PKCEFlow) newClient(httpClient HttpClient, user string, opts []ClientOption) (Client, error) {
+	accountsURL, err := f.accountsBaseURL()
+	if err != nil {
+		return Client{}, err
+	}
+
+	flowOpts := []ClientOption{WithHttpClient(httpClient)}
+	if accountsURL != "" {
+		flowOpts = append(flowOpts, WithAccountsBaseURL(accountsURL))
+	}
+	if user != "" {
+		flowOpts = append(flowOpts, WithUser(user))
+	}
+
+	c, err := New(append(flowOpts, opts...)...)
+	if err != nil {
+		return Client{}, err
+	}
+
+	c.id = f.ClientID
+	return c, nil
+}
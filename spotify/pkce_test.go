@@ -0,0 +1,200 @@
+package spotify
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestNewPKCEFlow(t *testing.T) {
+	testcases := map[string]struct {
+		id            string
+		redirectURI   string
+		expectedError error
+	}{
+		"client id is missing -- should fail": {
+			redirectURI: "http://localhost:8080/callback",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"redirect uri is missing -- should fail": {
+			id: "id",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"valid inputs -- returns a new flow": {
+			id:          "id",
+			redirectURI: "http://localhost:8080/callback",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := NewPKCEFlow(tc.id, tc.redirectURI, "playlist-modify-private")
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if len(got.Verifier) < 43 || len(got.Verifier) > 128 {
+				t.Errorf("NewPKCEFlow() verifier has an invalid length of '%d'", len(got.Verifier))
+			}
+
+			if got.State == "" {
+				t.Error("NewPKCEFlow() state was not generated")
+			}
+		})
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// example taken from https://datatracker.ietf.org/doc/html/rfc7636#appendix-B
+	got := codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if got != want {
+		t.Errorf("codeChallenge() mismatch, \n - got: '%s', \n - want: '%s'", got, want)
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	flow := PKCEFlow{
+		ClientID:    "id",
+		RedirectURI: "http://localhost:8080/callback",
+		Scopes:      []string{"playlist-read-private", "playlist-modify-private"},
+		State:       "state",
+		Verifier:    "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk",
+	}
+
+	u, err := url.Parse(flow.AuthURL())
+	if err != nil {
+		t.Fatalf("PKCEFlow.AuthURL() returned an invalid url, %s", err.Error())
+	}
+
+	want := map[string]string{
+		"client_id":             "id",
+		"response_type":         "code",
+		"redirect_uri":          "http://localhost:8080/callback",
+		"code_challenge_method": "S256",
+		"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		"state":                 "state",
+		"scope":                 "playlist-read-private playlist-modify-private",
+	}
+
+	q := u.Query()
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("PKCEFlow.AuthURL() mismatch for '%s', \n - got: '%s', \n - want: '%s'", k, q.Get(k), v)
+		}
+	}
+}
+
+func TestValidateState(t *testing.T) {
+	testcases := map[string]struct {
+		flow          PKCEFlow
+		state         string
+		expectedError error
+	}{
+		"flow has no state -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"state does not match -- should fail": {
+			flow:  PKCEFlow{State: "state"},
+			state: "other",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"state matches": {
+			flow:  PKCEFlow{State: "state"},
+			state: "state",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			err := tc.flow.ValidateState(tc.state)
+			checkSpotifyError(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestExchange(t *testing.T) {
+	flow := PKCEFlow{
+		ClientID:    "id",
+		RedirectURI: "http://localhost:8080/callback",
+		State:       "state",
+		Verifier:    "verifier",
+	}
+
+	testcases := map[string]struct {
+		httpClient    *mockHttpClient
+		code          string
+		expectedError error
+	}{
+		"code is missing -- should fail": {
+			httpClient: &mockHttpClient{},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"token request failed -- should fail": {
+			httpClient: &mockHttpClient{
+				expectedError: errMock,
+			},
+			code: "code",
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"response without access token -- should fail": {
+			httpClient: &mockHttpClient{
+				expectedResponse: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+				},
+			},
+			code: "code",
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"successfully exchanged code": {
+			httpClient: &mockHttpClient{
+				expectedResponse: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"access_token": "access", "refresh_token": "refresh"}`)),
+				},
+			},
+			code: "code",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			c, err := flow.Exchange(tc.httpClient, tc.code, "user")
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if got := c.Token(); got.AccessToken != "access" || got.RefreshToken != "refresh" {
+				t.Errorf("PKCEFlow.Exchange() unexpected tokens, got access: '%s', refresh: '%s'", got.AccessToken, got.RefreshToken)
+			}
+
+			if err := tc.httpClient.gotRequest.ParseForm(); err != nil {
+				t.Fatalf("PKCEFlow.Exchange() sent an invalid form, %s", err.Error())
+			}
+
+			form := tc.httpClient.gotRequest.PostForm
+			if form.Get("grant_type") != "authorization_code" || form.Get("code_verifier") != "verifier" || form.Get("code") != "code" {
+				t.Errorf("PKCEFlow.Exchange() sent unexpected form values: '%v'", form)
+			}
+		})
+	}
+}
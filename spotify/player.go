@@ -0,0 +1,408 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// decodeTrackOrEpisode decodes an object that is either a track or an episode,
+// the type of the object decides what it actually is. Both are nil for a null object.
+func decodeTrackOrEpisode(raw json.RawMessage) (*Track, *Episode, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil, nil
+	}
+
+	var kind struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(raw, &kind)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if kind.Type == "episode" {
+		var e Episode
+		err = json.Unmarshal(raw, &e)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &e, nil
+	}
+
+	var t Track
+	err = json.Unmarshal(raw, &t)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &t, nil, nil
+}
+
+// PlayingItem is the item of the player, which is either a track or an episode of a podcast.
+// Exactly one of Track and Episode is set.
+type PlayingItem struct {
+	Track   *Track
+	Episode *Episode
+}
+
+func (p *PlayingItem) UnmarshalJSON(data []byte) error {
+	var err error
+	p.Track, p.Episode, err = decodeTrackOrEpisode(data)
+	return err
+}
+
+func (p PlayingItem) MarshalJSON() ([]byte, error) {
+	if p.Episode != nil {
+		return json.Marshal(p.Episode)
+	}
+
+	return json.Marshal(p.Track)
+}
+
+// Device is a device the user can play on, e.g. a phone or a speaker.
+type Device struct {
+	ID               string `json:"id"`
+	IsActive         bool   `json:"is_active"`
+	IsPrivateSession bool   `json:"is_private_session"`
+	// IsRestricted is true if the device is not accepting commands of the api.
+	IsRestricted   bool   `json:"is_restricted"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	SupportsVolume bool   `json:"supports_volume"`
+	// VolumePercent is nil if the volume of the device is unknown.
+	VolumePercent *int `json:"volume_percent"`
+}
+
+// RepeatMode is the repeat mode of the player.
+type RepeatMode string
+
+const (
+	RepeatOff     RepeatMode = "off"
+	RepeatTrack   RepeatMode = "track"
+	RepeatContext RepeatMode = "context"
+)
+
+// PlaybackActions are the actions that are not allowed in the current state of the player.
+type PlaybackActions struct {
+	Disallows map[string]bool `json:"disallows"`
+}
+
+// PlaybackState is the state of the player described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-information-about-the-users-current-playback
+// The currently playing endpoint is returning the same object without the device, shuffle and repeat state.
+type PlaybackState struct {
+	Device       Device           `json:"device"`
+	RepeatState  RepeatMode       `json:"repeat_state"`
+	ShuffleState bool             `json:"shuffle_state"`
+	Context      *PlaybackContext `json:"context"`
+	// Timestamp is the unix time in milliseconds the state was changed the last time.
+	Timestamp  int64 `json:"timestamp"`
+	ProgressMs int   `json:"progress_ms"`
+	IsPlaying  bool  `json:"is_playing"`
+	// Item is nil e.g. during an advertisement.
+	Item                 *PlayingItem    `json:"item"`
+	CurrentlyPlayingType string          `json:"currently_playing_type"`
+	Actions              PlaybackActions `json:"actions"`
+}
+
+// Queue is the currently playing item and the items in the queue of the user.
+type Queue struct {
+	CurrentlyPlaying *PlayingItem  `json:"currently_playing"`
+	Queue            []PlayingItem `json:"queue"`
+}
+
+// playerURL returns the url of a player endpoint with the given query,
+// the device id is optional and targets the active device if empty.
+func playerURL(path string, q url.Values, deviceID string) string {
+	if q == nil {
+		q = url.Values{}
+	}
+	if deviceID != "" {
+		q.Set("device_id", deviceID)
+	}
+
+	u := endpoint("me", "player")
+	if path != "" {
+		u += "/" + path
+	}
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	return u
+}
+
+// playbackStateURL returns the url of the state endpoints, which are supporting episodes as well.
+func playbackStateURL(path, market string) string {
+	q := url.Values{"additional_types": {"track,episode"}}
+	if market != "" {
+		q.Set("market", market)
+	}
+
+	return playerURL(path, q, "")
+}
+
+// GetPlaybackState returns the state of the player as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-information-about-the-users-current-playback
+// It is nil if there is no active device. It requires the user-read-playback-state scope.
+func (c *Client) GetPlaybackState(ctx context.Context, market string) (*PlaybackState, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	var state *PlaybackState
+	err = c.getOptional(ctx, playbackStateURL("", market), &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// GetCurrentlyPlaying returns the item the user is currently playing as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-the-users-currently-playing-track
+// It is nil if nothing is playing. It requires the user-read-currently-playing scope.
+func (c *Client) GetCurrentlyPlaying(ctx context.Context, market string) (*PlaybackState, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	var state *PlaybackState
+	err = c.getOptional(ctx, playbackStateURL("currently-playing", market), &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// GetDevices returns the devices of the user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-users-available-devices
+// It requires the user-read-playback-state scope.
+func (c *Client) GetDevices(ctx context.Context) ([]Device, error) {
+	var resp struct {
+		Devices []Device `json:"devices"`
+	}
+	err := c.get(ctx, playerURL("devices", nil, ""), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Devices, nil
+}
+
+// TransferPlayback moves the playback to the given device as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/transfer-a-users-playback
+// If play is false, the current state of the player is kept. It requires the user-modify-playback-state scope,
+// like all of the following player commands.
+func (c *Client) TransferPlayback(ctx context.Context, deviceID string, play bool) error {
+	if deviceID == "" {
+		return newError(invalidInputs, "device id is required", nil)
+	}
+
+	payload := struct {
+		DeviceIDs []string `json:"device_ids"`
+		Play      bool     `json:"play"`
+	}{DeviceIDs: []string{deviceID}, Play: play}
+
+	return c.send(ctx, http.MethodPut, playerURL("", nil, ""), payload, http.StatusNoContent, nil)
+}
+
+// PlayOffset is the position in the context or the uris the playback starts at.
+// Either the zero based Position or the URI of an item is used, the URI wins if both are set.
+type PlayOffset struct {
+	Position int
+	URI      spotifyuri.URI
+}
+
+func (o PlayOffset) MarshalJSON() ([]byte, error) {
+	if !o.URI.IsZero() {
+		return json.Marshal(map[string]string{"uri": o.URI.String()})
+	}
+
+	return json.Marshal(map[string]int{"position": o.Position})
+}
+
+// PlayOptions are the parameters of Play. Without a context and uris the paused playback is resumed.
+type PlayOptions struct {
+	// DeviceID is the device to play on, the active device is used if empty.
+	DeviceID string
+	// ContextURI is an album, artist or playlist to play, e.g. "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M".
+	ContextURI spotifyuri.URI
+	// URIs are the tracks or episodes to play, they can not be combined with a context.
+	URIs []spotifyuri.URI
+	// Offset is the item the playback starts at, it is only valid with a context or uris.
+	Offset *PlayOffset
+	// PositionMs is the position in the first item the playback starts at.
+	PositionMs int
+}
+
+func (o PlayOptions) validate() error {
+	if !o.ContextURI.IsZero() && len(o.URIs) > 0 {
+		return newError(invalidInputs, "either a context or uris can be played, not both", nil)
+	}
+
+	if o.Offset != nil && o.ContextURI.IsZero() && len(o.URIs) == 0 {
+		return newError(invalidInputs, "an offset requires a context or uris", nil)
+	}
+
+	if o.Offset != nil && o.Offset.Position < 0 {
+		return newError(invalidInputs, "offset position can not be negative", nil)
+	}
+
+	if o.PositionMs < 0 {
+		return newError(invalidInputs, "position can not be negative", nil)
+	}
+
+	if !o.ContextURI.IsZero() {
+		switch o.ContextURI.Kind {
+		case spotifyuri.KindAlbum, spotifyuri.KindArtist, spotifyuri.KindPlaylist, spotifyuri.KindShow, spotifyuri.KindAudiobook:
+		default:
+			return newError(invalidInputs, "'"+o.ContextURI.String()+"' can not be played as context", nil)
+		}
+
+		err := o.ContextURI.Validate()
+		if err != nil {
+			return newError(invalidInputs, "invalid context uri", err)
+		}
+	}
+
+	for _, uri := range o.URIs {
+		err := validatePlayable(uri)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePlayable checks that the uri is a valid track or episode.
+func validatePlayable(uri spotifyuri.URI) error {
+	if uri.Kind != spotifyuri.KindTrack && uri.Kind != spotifyuri.KindEpisode {
+		return newError(invalidInputs, "only tracks and episodes can be played, got '"+uri.String()+"'", nil)
+	}
+
+	err := uri.Validate()
+	if err != nil {
+		return newError(invalidInputs, "invalid uri", err)
+	}
+
+	return nil
+}
+
+// Play starts or resumes the playback as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/start-a-users-playback
+// A freshly generated playlist can be played right away by passing its uri as ContextURI.
+func (c *Client) Play(ctx context.Context, opts PlayOptions) error {
+	err := opts.validate()
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		ContextURI string           `json:"context_uri,omitempty"`
+		URIs       []spotifyuri.URI `json:"uris,omitempty"`
+		Offset     *PlayOffset      `json:"offset,omitempty"`
+		PositionMs int              `json:"position_ms,omitempty"`
+	}{
+		ContextURI: opts.ContextURI.String(),
+		URIs:       opts.URIs,
+		Offset:     opts.Offset,
+		PositionMs: opts.PositionMs,
+	}
+
+	return c.send(ctx, http.MethodPut, playerURL("play", nil, opts.DeviceID), payload, http.StatusNoContent, nil)
+}
+
+// Pause pauses the playback as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/pause-a-users-playback
+// The device id is optional, the active device is used if it is empty.
+func (c *Client) Pause(ctx context.Context, deviceID string) error {
+	return c.send(ctx, http.MethodPut, playerURL("pause", nil, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// SkipToNext skips to the next item in the queue as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/skip-users-playback-to-next-track
+func (c *Client) SkipToNext(ctx context.Context, deviceID string) error {
+	return c.send(ctx, http.MethodPost, playerURL("next", nil, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// SkipToPrevious skips to the previous item as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/skip-users-playback-to-previous-track
+func (c *Client) SkipToPrevious(ctx context.Context, deviceID string) error {
+	return c.send(ctx, http.MethodPost, playerURL("previous", nil, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// Seek jumps to the position in milliseconds of the current item as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/seek-to-position-in-currently-playing-track
+func (c *Client) Seek(ctx context.Context, positionMs int, deviceID string) error {
+	if positionMs < 0 {
+		return newError(invalidInputs, "position can not be negative", nil)
+	}
+
+	q := url.Values{"position_ms": {strconv.Itoa(positionMs)}}
+	return c.send(ctx, http.MethodPut, playerURL("seek", q, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// SetVolume sets the volume in percent as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/set-volume-for-users-playback
+func (c *Client) SetVolume(ctx context.Context, percent int, deviceID string) error {
+	if percent < 0 || percent > 100 {
+		return newError(invalidInputs, "volume has to be between 0 and 100, got "+strconv.Itoa(percent), nil)
+	}
+
+	q := url.Values{"volume_percent": {strconv.Itoa(percent)}}
+	return c.send(ctx, http.MethodPut, playerURL("volume", q, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// SetShuffle turns shuffle on or off as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/toggle-shuffle-for-users-playback
+func (c *Client) SetShuffle(ctx context.Context, shuffle bool, deviceID string) error {
+	q := url.Values{"state": {strconv.FormatBool(shuffle)}}
+	return c.send(ctx, http.MethodPut, playerURL("shuffle", q, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// SetRepeat sets the repeat mode as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/set-repeat-mode-on-users-playback
+func (c *Client) SetRepeat(ctx context.Context, mode RepeatMode, deviceID string) error {
+	switch mode {
+	case RepeatOff, RepeatTrack, RepeatContext:
+	default:
+		return newError(invalidInputs, "unknown repeat mode '"+string(mode)+"'", nil)
+	}
+
+	q := url.Values{"state": {string(mode)}}
+	return c.send(ctx, http.MethodPut, playerURL("repeat", q, deviceID), nil, http.StatusNoContent, nil)
+}
+
+// GetQueue returns the queue of the user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-queue
+// It requires the user-read-playback-state scope.
+func (c *Client) GetQueue(ctx context.Context) (Queue, error) {
+	var q Queue
+	err := c.get(ctx, playerURL("queue", nil, ""), &q)
+	if err != nil {
+		return Queue{}, err
+	}
+
+	return q, nil
+}
+
+// AddToQueue adds a track or an episode to the end of the queue as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/add-to-queue
+func (c *Client) AddToQueue(ctx context.Context, uri spotifyuri.URI, deviceID string) error {
+	err := validatePlayable(uri)
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{"uri": {uri.String()}}
+	return c.send(ctx, http.MethodPost, playerURL("queue", q, deviceID), nil, http.StatusNoContent, nil)
+}
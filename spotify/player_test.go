@@ -0,0 +1,221 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// mockURI returns a valid uri of the given kind with an id made of n.
+func mockURI(kind spotifyuri.Kind, n int) spotifyuri.URI {
+	return spotifyuri.URI{Kind: kind, ID: spotifyuri.ID(fmt.Sprintf("%022d", n))}
+}
+
+func TestPlay(t *testing.T) {
+	testcases := map[string]struct {
+		opts          PlayOptions
+		expectedURL   string
+		expectedBody  string
+		expectedError error
+	}{
+		"context and uris -- should fail": {
+			opts: PlayOptions{ContextURI: mockURI(spotifyuri.KindPlaylist, 1), URIs: []spotifyuri.URI{mockURI(spotifyuri.KindTrack, 1)}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"offset without context -- should fail": {
+			opts: PlayOptions{Offset: &PlayOffset{Position: 1}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid uri -- should fail": {
+			opts: PlayOptions{URIs: []spotifyuri.URI{{Kind: spotifyuri.KindTrack, ID: "1"}}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"album as item -- should fail": {
+			opts: PlayOptions{URIs: []spotifyuri.URI{mockURI(spotifyuri.KindAlbum, 1)}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"track as context -- should fail": {
+			opts: PlayOptions{ContextURI: mockURI(spotifyuri.KindTrack, 1)},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"resume": {
+			expectedURL:  baseURL + "/me/player/play",
+			expectedBody: `{}`,
+		},
+		"play a playlist on a device": {
+			opts: PlayOptions{
+				DeviceID:   "device",
+				ContextURI: mockURI(spotifyuri.KindPlaylist, 1),
+				Offset:     &PlayOffset{Position: 3},
+				PositionMs: 1000,
+			},
+			expectedURL:  baseURL + "/me/player/play?device_id=device",
+			expectedBody: `{"context_uri":"spotify:playlist:0000000000000000000001","offset":{"position":3},"position_ms":1000}`,
+		},
+		"play uris starting at an uri": {
+			opts: PlayOptions{
+				URIs:   []spotifyuri.URI{mockURI(spotifyuri.KindTrack, 1), mockURI(spotifyuri.KindEpisode, 2)},
+				Offset: &PlayOffset{URI: mockURI(spotifyuri.KindEpisode, 2)},
+			},
+			expectedURL:  baseURL + "/me/player/play",
+			expectedBody: `{"uris":["spotify:track:0000000000000000000001","spotify:episode:0000000000000000000002"],"offset":{"uri":"spotify:episode:0000000000000000000002"}}`,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.Play(context.Background(), tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if httpClient.gotRequest.Method != http.MethodPut || httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected request '%s %s', want '%s'", httpClient.gotRequest.Method, httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+
+			body, _ := io.ReadAll(httpClient.gotRequest.Body)
+			if string(body) != tc.expectedBody {
+				t.Errorf("unexpected body, \n - got: '%s', \n - want: '%s'", string(body), tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestGetPlaybackState(t *testing.T) {
+	testcases := map[string]struct {
+		resp            *http.Response
+		expectedTrack   string
+		expectedEpisode string
+		expectNil       bool
+	}{
+		"no active device -- return nil": {
+			resp:      &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody},
+			expectNil: true,
+		},
+		"playing a track": {
+			resp:          mockTokenResponse(`{"is_playing": true, "item": {"type": "track", "id": "track"}}`),
+			expectedTrack: "track",
+		},
+		"playing an episode": {
+			resp:            mockTokenResponse(`{"is_playing": true, "item": {"type": "episode", "id": "episode"}}`),
+			expectedEpisode: "episode",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: tc.resp}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			got, err := c.GetPlaybackState(context.Background(), "")
+			if err != nil {
+				t.Fatalf("spotify.Client.GetPlaybackState() unexpected error '%s'", err.Error())
+			}
+
+			if want := baseURL + "/me/player?additional_types=track%2Cepisode"; httpClient.gotRequest.URL.String() != want {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), want)
+			}
+
+			if tc.expectNil {
+				if got != nil {
+					t.Errorf("spotify.Client.GetPlaybackState() expected nil, got '%+v'", got)
+				}
+				return
+			}
+
+			if got == nil || got.Item == nil {
+				t.Fatalf("spotify.Client.GetPlaybackState() expected an item, got '%+v'", got)
+			}
+
+			if tc.expectedTrack != "" && (got.Item.Track == nil || got.Item.Track.ID != tc.expectedTrack) {
+				t.Errorf("spotify.Client.GetPlaybackState() expected track '%s', got '%+v'", tc.expectedTrack, got.Item)
+			}
+
+			if tc.expectedEpisode != "" && (got.Item.Episode == nil || got.Item.Episode.ID != tc.expectedEpisode) {
+				t.Errorf("spotify.Client.GetPlaybackState() expected episode '%s', got '%+v'", tc.expectedEpisode, got.Item)
+			}
+		})
+	}
+}
+
+func TestPlayerErrorReasons(t *testing.T) {
+	testcases := map[string]struct {
+		reason   string
+		expected error
+	}{
+		"no active device": {
+			reason:   "NO_ACTIVE_DEVICE",
+			expected: ErrNoActiveDevice,
+		},
+		"premium required": {
+			reason:   "PREMIUM_REQUIRED",
+			expected: ErrPremiumRequired,
+		},
+		"unknown reason -- request failed": {
+			reason:   "UNKNOWN",
+			expected: errSpotify{code: requestFailed},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			resp := errResponse{}
+			resp.Err.Status = http.StatusNotFound
+			resp.Err.Message = "Player command failed"
+			resp.Err.Reason = tc.reason
+
+			c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusNotFound, resp),
+			}})
+
+			err := c.Pause(context.Background(), "")
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("spotify.Client.Pause() expected '%v', got '%v'", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestPlayerCommandInvalidInputs(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{}})
+	ctx := context.Background()
+
+	for name, err := range map[string]error{
+		"SetVolume":        c.SetVolume(ctx, 101, ""),
+		"Seek":             c.Seek(ctx, -1, ""),
+		"SetRepeat":        c.SetRepeat(ctx, "mock", ""),
+		"AddToQueue":       c.AddToQueue(ctx, spotifyuri.URI{Kind: spotifyuri.KindTrack, ID: "mock"}, ""),
+		"TransferPlayback": c.TransferPlayback(ctx, "", true),
+	} {
+		if !errors.Is(err, errSpotify{code: invalidInputs}) {
+			t.Errorf("spotify.Client.%s() expected an invalid inputs error, got '%v'", name, err)
+		}
+	}
+}
+
+func TestNoContentOnlyAcceptedWhereDocumented(t *testing.T) {
+	httpClient := &mockHttpClient{expectedResponse: &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+	_, err := c.GetDevices(context.Background())
+	checkSpotifyError(t, errSpotify{code: requestFailed}, err)
+}
@@ -0,0 +1,188 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// PlaylistTracksRef is the reference to the tracks of a playlist,
+// that is included in simplified playlist objects instead of the tracks themselves.
+type PlaylistTracksRef struct {
+	Href  string `json:"href"`
+	Total int64  `json:"total"`
+}
+
+// SimplePlaylist is the simplified playlist object, as it is returned e.g. in the list
+// of the playlists of a user:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-list-users-playlists
+// Fields that are not part of the model are kept in Extra.
+type SimplePlaylist struct {
+	Collaborative bool              `json:"collaborative"`
+	Description   string            `json:"description"`
+	ExternalURLs  ExternalURLs      `json:"external_urls"`
+	Followers     *Followers        `json:"followers,omitempty"`
+	Href          string            `json:"href"`
+	ID            string            `json:"id"`
+	Images        []Image           `json:"images"`
+	Name          string            `json:"name"`
+	Owner         SimpleUser        `json:"owner"`
+	Public        bool              `json:"public"`
+	SnapshotID    string            `json:"snapshot_id"`
+	Tracks        PlaylistTracksRef `json:"tracks"`
+	Type          string            `json:"type"`
+	URI           string            `json:"uri"`
+	Extra         ExtraFields       `json:"-"`
+}
+
+// simplePlaylist is needed to decode a SimplePlaylist without calling its UnmarshalJSON again.
+type simplePlaylist SimplePlaylist
+
+func (p *SimplePlaylist) UnmarshalJSON(data []byte) error {
+	var s simplePlaylist
+	extra, err := unmarshalWithExtra(data, &s)
+	if err != nil {
+		return err
+	}
+
+	s.Extra = extra
+	*p = SimplePlaylist(s)
+
+	return nil
+}
+
+func (p SimplePlaylist) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(simplePlaylist(p), p.Extra)
+}
+
+// GetPlaylist returns the playlist with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-playlist
+// The market is optional and defaults to the market of the client, see SetMarket.
+func (c *Client) GetPlaylist(ctx context.Context, id spotifyuri.ID, market string) (Playlist, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Playlist{}, err
+	}
+
+	err = validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return Playlist{}, err
+	}
+
+	var p Playlist
+	err = c.get(ctx, endpoint("playlists", string(id))+marketQuery(market), &p)
+	if err != nil {
+		return Playlist{}, err
+	}
+
+	return p, nil
+}
+
+// UpdatePlaylistPayload holds the details of a playlist that should be changed.
+// Fields that are nil are not changed.
+type UpdatePlaylistPayload struct {
+	Name          *string `json:"name,omitempty"`
+	Public        *bool   `json:"public,omitempty"`
+	Collaborative *bool   `json:"collaborative,omitempty"`
+	Description   *string `json:"description,omitempty"`
+}
+
+func (p UpdatePlaylistPayload) validate() error {
+	if p.Name == nil && p.Public == nil && p.Collaborative == nil && p.Description == nil {
+		return newError(invalidInputs, "at least one playlist detail has to be changed", nil)
+	}
+
+	if p.Name != nil && *p.Name == "" {
+		return newError(invalidInputs, "playlist name can not be empty", nil)
+	}
+
+	// spotify only allows collaborative playlists, that are not public:
+	if p.Collaborative != nil && *p.Collaborative && (p.Public == nil || *p.Public) {
+		return newError(invalidInputs, "collaborative playlists have to be set to non public", nil)
+	}
+
+	return nil
+}
+
+// UpdatePlaylist changes the details of a playlist the user owns as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/change-playlist-details
+func (c *Client) UpdatePlaylist(ctx context.Context, id spotifyuri.ID, payload UpdatePlaylistPayload) error {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return err
+	}
+
+	err = payload.validate()
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, http.MethodPut, endpoint("playlists", string(id)), payload, http.StatusOK, nil)
+}
+
+// UnfollowPlaylist removes the playlist from the library of the user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/unfollow-playlist
+// Spotify has no way of deleting a playlist, unfollowing a playlist the user owns is the same as deleting it.
+func (c *Client) UnfollowPlaylist(ctx context.Context, id spotifyuri.ID) error {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, http.MethodDelete, endpoint("playlists", string(id), "followers"), nil, http.StatusOK, nil)
+}
+
+// FollowPlaylist adds the playlist to the library of the current user as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/follow-playlist
+// If public is false, the playlist is not shown on the profile of the user.
+func (c *Client) FollowPlaylist(ctx context.Context, id spotifyuri.ID, public bool) error {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		Public bool `json:"public"`
+	}{Public: public}
+
+	return c.send(ctx, http.MethodPut, endpoint("playlists", string(id), "followers"), payload, http.StatusOK, nil)
+}
+
+// maxPlaylistFollowersPerRequest is the maximum number of user ids of a single check of the followers of a playlist.
+const maxPlaylistFollowersPerRequest = 5
+
+// PlaylistFollowersContain checks if the users are following the playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/check-if-user-follows-playlist
+// The results are in the same order as the user ids. Spotify is only able to check the followers
+// of public playlists, unless the current user is checked.
+func (c *Client) PlaylistFollowersContain(ctx context.Context, id spotifyuri.ID, userIDs []spotifyuri.ID) ([]bool, error) {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]bool, len(userIDs))
+	err = batch(ctx, spotifyuri.KindUser, userIDs, maxPlaylistFollowersPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp []bool
+		err := c.get(ctx, endpoint("playlists", string(id), "followers", "contains")+idsQuery(chunk, ""), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// SimplePlaylistPage is a page of simplified playlists, e.g. of a search.
+type SimplePlaylistPage struct {
+	Href  string           `json:"href"`
+	Items []SimplePlaylist `json:"items"`
+	Pagination
+}
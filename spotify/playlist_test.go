@@ -0,0 +1,268 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestSimplePlaylistJSON(t *testing.T) {
+	data := []byte(`{
+		"collaborative": false,
+		"description": "mock description",
+		"external_urls": {"spotify": "https://open.spotify.com/playlist/1234"},
+		"href": "https://api.spotify.com/v1/playlists/1234",
+		"id": "1234",
+		"images": [{"url": "https://i.scdn.co/image/1234", "height": 640, "width": 640}],
+		"name": "mock",
+		"owner": {"display_name": "Mock User", "id": "user", "type": "user", "uri": "spotify:user:user"},
+		"primary_color": "#ffffff",
+		"public": true,
+		"snapshot_id": "snapshot",
+		"tracks": {"href": "https://api.spotify.com/v1/playlists/1234/tracks", "total": 12},
+		"type": "playlist",
+		"uri": "spotify:playlist:1234"
+	}`)
+
+	want := SimplePlaylist{
+		Description:  "mock description",
+		ExternalURLs: ExternalURLs{Spotify: "https://open.spotify.com/playlist/1234"},
+		Href:         "https://api.spotify.com/v1/playlists/1234",
+		ID:           "1234",
+		Images:       []Image{{URL: "https://i.scdn.co/image/1234", Height: 640, Width: 640}},
+		Name:         "mock",
+		Owner: SimpleUser{
+			DisplayName: "Mock User",
+			ID:          "user",
+			Type:        "user",
+			URI:         "spotify:user:user",
+		},
+		Public:     true,
+		SnapshotID: "snapshot",
+		Tracks:     PlaylistTracksRef{Href: "https://api.spotify.com/v1/playlists/1234/tracks", Total: 12},
+		Type:       "playlist",
+		URI:        "spotify:playlist:1234",
+		Extra:      ExtraFields{"primary_color": json.RawMessage(`"#ffffff"`)},
+	}
+
+	var got SimplePlaylist
+	err := json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("SimplePlaylist.UnmarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SimplePlaylist.UnmarshalJSON() mismatch (-want +got):\n%s", diff)
+	}
+
+	// the extra fields have to survive a round trip:
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("SimplePlaylist.MarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	var again SimplePlaylist
+	err = json.Unmarshal(out, &again)
+	if err != nil {
+		t.Fatalf("SimplePlaylist.UnmarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(want, again); diff != "" {
+		t.Errorf("SimplePlaylist round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPlaylist(t *testing.T) {
+	testcases := map[string]struct {
+		client         Client
+		id             spotifyuri.ID
+		expectedError  error
+		expectedOutput Playlist
+	}{
+		"id is missing -- should fail": {
+			client: mockAuthorizedClient(Client{}),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"not authorized -- should fail": {
+			id: mockID,
+			expectedError: errSpotify{
+				code: notAuthorized,
+			},
+		},
+		"request failed -- return error": {
+			client: mockAuthorizedClient(Client{
+				httpClient: &mockHttpClient{
+					expectedError: errMock,
+				},
+			}),
+			id: mockID,
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"successfully retrieved playlist": {
+			client: mockAuthorizedClient(Client{
+				httpClient: &mockHttpClient{
+					expectedResponse: mockTokenResponse(`{
+						"id": "1234",
+						"name": "mock",
+						"tracks": {
+							"href": "https://api.spotify.com/v1/playlists/1234/tracks",
+							"items": [{"is_local": false, "track": {"id": "track", "name": "mock track", "type": "track"}}],
+							"limit": 100,
+							"next": "https://api.spotify.com/v1/playlists/1234/tracks?offset=100&limit=100",
+							"total": 101
+						}
+					}`),
+				},
+			}),
+			id: mockID,
+			expectedOutput: Playlist{
+				ID:   "1234",
+				Name: "mock",
+				Tracks: PlaylistItemsPage{
+					Href:  "https://api.spotify.com/v1/playlists/1234/tracks",
+					Items: []PlaylistItem{{Track: &Track{ID: "track", Name: "mock track", Type: "track"}}},
+					Pagination: Pagination{
+						Limit: 100,
+						Next:  "https://api.spotify.com/v1/playlists/1234/tracks?offset=100&limit=100",
+						Total: 101,
+					},
+				},
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			out, err := tc.client.GetPlaylist(context.Background(), tc.id, "")
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.expectedOutput, out); diff != "" {
+				t.Errorf("spotify.Client.GetPlaylist() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdatePlaylist(t *testing.T) {
+	name := "new name"
+	empty := ""
+	yes := true
+	no := false
+
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		payload       UpdatePlaylistPayload
+		expectedError error
+		expectedBody  string
+	}{
+		"id is missing -- should fail": {
+			payload: UpdatePlaylistPayload{Name: &name},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"nothing to change -- should fail": {
+			id: mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"empty name -- should fail": {
+			id:      mockID,
+			payload: UpdatePlaylistPayload{Name: &empty},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"public collaborative playlist -- should fail": {
+			id:      mockID,
+			payload: UpdatePlaylistPayload{Collaborative: &yes, Public: &yes},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"only changed fields are sent": {
+			id:           mockID,
+			payload:      UpdatePlaylistPayload{Name: &name, Collaborative: &yes, Public: &no},
+			expectedBody: `{"name":"new name","public":false,"collaborative":true}`,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusOK, nil),
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.UpdatePlaylist(context.Background(), tc.id, tc.payload)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			r := httpClient.gotRequest
+			if r.Method != http.MethodPut || r.URL.String() != baseURL+"/playlists/"+mockID {
+				t.Errorf("spotify.Client.UpdatePlaylist() unexpected request '%s %s'", r.Method, r.URL)
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != tc.expectedBody {
+				t.Errorf("spotify.Client.UpdatePlaylist() body mismatch, \n - got: '%s', \n - want: '%s'", body, tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestUnfollowPlaylist(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		response      *http.Response
+		expectedError error
+	}{
+		"id is missing -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"api responds with an error -- should fail": {
+			id:       mockID,
+			response: createMockedHttpResponse(t, http.StatusForbidden, nil),
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"successfully unfollowed playlist": {
+			id:       mockID,
+			response: createMockedHttpResponse(t, http.StatusOK, nil),
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: tc.response}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			err := c.UnfollowPlaylist(context.Background(), tc.id)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			r := httpClient.gotRequest
+			if r.Method != http.MethodDelete || r.URL.String() != baseURL+"/playlists/"+mockID+"/followers" {
+				t.Errorf("spotify.Client.UnfollowPlaylist() unexpected request '%s %s'", r.Method, r.URL)
+			}
+		})
+	}
+}
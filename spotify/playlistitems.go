@@ -0,0 +1,349 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// maxPlaylistItems is the maximum number of items that can be added or removed in a single request.
+const maxPlaylistItems = 100
+
+type snapshotResponse struct {
+	SnapshotID string `json:"snapshot_id"`
+}
+
+// validatePlaylistItems checks the playlist id and that the uris are tracks or episodes.
+// Local files can only be removed from a playlist, but not added.
+func validatePlaylistItems(id spotifyuri.ID, uris []spotifyuri.URI, allowLocal bool) error {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return err
+	}
+
+	for _, uri := range uris {
+		err := uri.Validate()
+		if err != nil {
+			return newError(invalidInputs, "invalid playlist item", err)
+		}
+
+		if uri.Kind == spotifyuri.KindLocal && allowLocal {
+			continue
+		}
+
+		if uri.Kind != spotifyuri.KindTrack && uri.Kind != spotifyuri.KindEpisode {
+			return newError(invalidInputs, "only tracks and episodes can be playlist items, got '"+uri.String()+"'", nil)
+		}
+	}
+
+	return nil
+}
+
+// batches splits the uris into chunks of at most size items.
+func batches(uris []spotifyuri.URI, size int) [][]spotifyuri.URI {
+	var out [][]spotifyuri.URI
+	for len(uris) > size {
+		out = append(out, uris[:size])
+		uris = uris[size:]
+	}
+
+	if len(uris) > 0 {
+		out = append(out, uris)
+	}
+
+	return out
+}
+
+// AddPlaylistItems adds tracks or episodes, given as spotify uris, to a playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/add-tracks-to-playlist
+// The items are inserted at the given zero based position, a negative position appends them.
+// More than 100 items are added in multiple requests, it returns the snapshot id of the last one.
+func (c *Client) AddPlaylistItems(ctx context.Context, id spotifyuri.ID, uris []spotifyuri.URI, position int) (string, error) {
+	err := validatePlaylistItems(id, uris, false)
+	if err != nil {
+		return "", err
+	}
+
+	if len(uris) == 0 {
+		return "", newError(invalidInputs, "at least one item is required", nil)
+	}
+
+	var snapshot string
+	for _, batch := range batches(uris, maxPlaylistItems) {
+		payload := struct {
+			URIs     []spotifyuri.URI `json:"uris"`
+			Position *int             `json:"position,omitempty"`
+		}{URIs: batch}
+
+		if position >= 0 {
+			p := position
+			payload.Position = &p
+			position += len(batch)
+		}
+
+		var resp snapshotResponse
+		err := c.send(ctx, http.MethodPost, endpoint("playlists", string(id), "tracks"), payload, http.StatusCreated, &resp)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot = resp.SnapshotID
+	}
+
+	return snapshot, nil
+}
+
+// RemovePlaylistItems removes all occurrences of the given uris from a playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/remove-tracks-playlist
+// The snapshot id is optional, if it is set the items are removed from this version of the playlist.
+// More than 100 items are removed in multiple requests, it returns the snapshot id of the last one.
+func (c *Client) RemovePlaylistItems(ctx context.Context, id spotifyuri.ID, uris []spotifyuri.URI, snapshotID string) (string, error) {
+	err := validatePlaylistItems(id, uris, true)
+	if err != nil {
+		return "", err
+	}
+
+	if len(uris) == 0 {
+		return "", newError(invalidInputs, "at least one item is required", nil)
+	}
+
+	type item struct {
+		URI spotifyuri.URI `json:"uri"`
+	}
+
+	// the snapshot is only returned once a request succeeded, the given one is not a result:
+	var snapshot string
+	for i, batch := range batches(uris, maxPlaylistItems) {
+		payload := struct {
+			Tracks     []item `json:"tracks"`
+			SnapshotID string `json:"snapshot_id,omitempty"`
+		}{SnapshotID: snapshotID}
+
+		// the following requests are removing the items from the version of the previous one:
+		if i > 0 {
+			payload.SnapshotID = snapshot
+		}
+
+		for _, uri := range batch {
+			payload.Tracks = append(payload.Tracks, item{URI: uri})
+		}
+
+		var resp snapshotResponse
+		err := c.send(ctx, http.MethodDelete, endpoint("playlists", string(id), "tracks"), payload, http.StatusOK, &resp)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot = resp.SnapshotID
+	}
+
+	return snapshot, nil
+}
+
+// ReorderPlaylistItemsPayload describes which range of items should be moved to which position.
+// The positions are zero based, see:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/reorder-or-replace-playlists-tracks
+type ReorderPlaylistItemsPayload struct {
+	RangeStart   int `json:"range_start"`
+	InsertBefore int `json:"insert_before"`
+	// RangeLength defaults to one item.
+	RangeLength int    `json:"range_length,omitempty"`
+	SnapshotID  string `json:"snapshot_id,omitempty"`
+}
+
+// ReorderPlaylistItems moves a range of items of a playlist and returns the new snapshot id.
+func (c *Client) ReorderPlaylistItems(ctx context.Context, id spotifyuri.ID, payload ReorderPlaylistItemsPayload) (string, error) {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return "", err
+	}
+
+	if payload.RangeStart < 0 || payload.InsertBefore < 0 || payload.RangeLength < 0 {
+		return "", newError(invalidInputs, "positions can not be negative", nil)
+	}
+
+	snapshot, err := c.putPlaylistItems(ctx, id, payload)
+	if err != nil {
+		return "", err
+	}
+
+	return snapshot, nil
+}
+
+// ReplacePlaylistItems replaces all items of a playlist with the given uris, an empty list clears the playlist.
+// Spotify only allows to replace up to 100 items at once, so the remaining items are added afterwards.
+// It returns the snapshot id of the last request.
+func (c *Client) ReplacePlaylistItems(ctx context.Context, id spotifyuri.ID, uris []spotifyuri.URI) (string, error) {
+	err := validatePlaylistItems(id, uris, false)
+	if err != nil {
+		return "", err
+	}
+
+	first := uris
+	if len(first) > maxPlaylistItems {
+		first = uris[:maxPlaylistItems]
+	}
+
+	payload := struct {
+		URIs []spotifyuri.URI `json:"uris"`
+	}{URIs: append([]spotifyuri.URI{}, first...)}
+
+	snapshot, err := c.putPlaylistItems(ctx, id, payload)
+	if err != nil {
+		return "", err
+	}
+
+	if len(uris) <= maxPlaylistItems {
+		return snapshot, nil
+	}
+
+	return c.AddPlaylistItems(ctx, id, uris[maxPlaylistItems:], -1)
+}
+
+// putPlaylistItems sends the given payload to the endpoint which reorders or replaces the items of a playlist.
+// Spotify answers the replace form with 201 instead of 200, so both statuses are a success.
+func (c *Client) putPlaylistItems(ctx context.Context, id spotifyuri.ID, payload interface{}) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", newError(internalError, "failed to marshal request payload", err)
+	}
+
+	var resp snapshotResponse
+	err = c.sendAccepting(ctx, http.MethodPut, endpoint("playlists", string(id), "tracks"), "application/json", body,
+		[]int{http.StatusOK, http.StatusCreated}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.SnapshotID, nil
+}
+
+// PlaylistItem is an entry of a playlist, which is either a track or an episode of a podcast.
+// Exactly one of Track and Episode is set, unless spotify removed the item from its catalog.
+type PlaylistItem struct {
+	// AddedAt and AddedBy are not set for very old playlists.
+	AddedAt time.Time  `json:"added_at"`
+	AddedBy SimpleUser `json:"added_by"`
+	// IsLocal is true for files of the user that are not available on spotify.
+	IsLocal bool     `json:"is_local"`
+	Track   *Track   `json:"-"`
+	Episode *Episode `json:"-"`
+}
+
+// playlistItem is needed to decode a PlaylistItem without calling its UnmarshalJSON again.
+type playlistItem PlaylistItem
+
+func (p *PlaylistItem) UnmarshalJSON(data []byte) error {
+	var item struct {
+		playlistItem
+		Track json.RawMessage `json:"track"`
+	}
+	err := json.Unmarshal(data, &item)
+	if err != nil {
+		return err
+	}
+
+	*p = PlaylistItem(item.playlistItem)
+
+	// spotify is using the "track" field for episodes as well:
+	p.Track, p.Episode, err = decodeTrackOrEpisode(item.Track)
+	return err
+}
+
+func (p PlaylistItem) MarshalJSON() ([]byte, error) {
+	item := struct {
+		playlistItem
+		Track interface{} `json:"track"`
+	}{playlistItem: playlistItem(p)}
+
+	if p.Track != nil {
+		item.Track = p.Track
+	} else if p.Episode != nil {
+		item.Track = p.Episode
+	}
+
+	return json.Marshal(item)
+}
+
+// PlaylistItemsPage is a page of the items of a playlist.
+type PlaylistItemsPage struct {
+	Href  string         `json:"href"`
+	Items []PlaylistItem `json:"items"`
+	Pagination
+}
+
+// PlaylistItemsOptions are the optional parameters of GetPlaylistItems.
+type PlaylistItemsOptions struct {
+	// Fields is filtering the response, e.g. "items(added_at,track(name,uri)),next".
+	// The paging fields have to be included to use PaginatePlaylistItems.
+	Fields string
+	// Market is an ISO 3166-1 alpha-2 country code, only items available in this market are returned.
+	Market string
+	PageOptions
+}
+
+func playlistItemsURL(id spotifyuri.ID, opts PlaylistItemsOptions) (string, error) {
+	err := validateID(spotifyuri.KindPlaylist, id)
+	if err != nil {
+		return "", err
+	}
+
+	err = opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	// episodes are only returned as episodes if the client says it is able to handle them:
+	q := url.Values{"additional_types": {"track,episode"}}
+	if opts.Fields != "" {
+		q.Set("fields", opts.Fields)
+	}
+	if opts.Market != "" {
+		q.Set("market", opts.Market)
+	}
+	opts.apply(q)
+
+	return endpoint("playlists", string(id), "tracks") + "?" + q.Encode(), nil
+}
+
+// GetPlaylistItems returns a page of the tracks and episodes of a playlist as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-playlists-tracks
+func (c *Client) GetPlaylistItems(ctx context.Context, id spotifyuri.ID, opts PlaylistItemsOptions) (PlaylistItemsPage, error) {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return PlaylistItemsPage{}, err
+	}
+	opts.Market = market
+
+	u, err := playlistItemsURL(id, opts)
+	if err != nil {
+		return PlaylistItemsPage{}, err
+	}
+
+	var page PlaylistItemsPage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return PlaylistItemsPage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginatePlaylistItems returns a paginator over all items of a playlist, see GetPlaylistItems.
+func (c *Client) PaginatePlaylistItems(ctx context.Context, id spotifyuri.ID, opts PlaylistItemsOptions) *Paginator {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+	opts.Market = market
+
+	u, err := playlistItemsURL(id, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	// the page options are already part of the url:
+	return c.newPaginator(ctx, u, PageOptions{})
+}
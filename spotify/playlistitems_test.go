@@ -0,0 +1,500 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func mockURIs(n int) []spotifyuri.URI {
+	out := make([]spotifyuri.URI, n)
+	for i := range out {
+		out[i] = mockURI(spotifyuri.KindTrack, i)
+	}
+	return out
+}
+
+// recordedRequest is a decoded request to the playlist items endpoint.
+type recordedRequest struct {
+	Method       string
+	URIs         int
+	Position     *int
+	Tracks       int
+	SnapshotID   string
+	RangeStart   int
+	InsertBefore int
+}
+
+// mockPlaylistItemsApi is recording every request and answers with an increasing snapshot id.
+func mockPlaylistItemsApi(t *testing.T, recorded *[]recordedRequest) mockHttpClientFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			URIs         []string          `json:"uris"`
+			Position     *int              `json:"position"`
+			Tracks       []json.RawMessage `json:"tracks"`
+			SnapshotID   string            `json:"snapshot_id"`
+			RangeStart   int               `json:"range_start"`
+			InsertBefore int               `json:"insert_before"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body, %s", err.Error())
+		}
+
+		if r.URL.String() != baseURL+"/playlists/"+mockID+"/tracks" {
+			t.Errorf("unexpected url '%s'", r.URL)
+		}
+
+		*recorded = append(*recorded, recordedRequest{
+			Method:       r.Method,
+			URIs:         len(body.URIs),
+			Position:     body.Position,
+			Tracks:       len(body.Tracks),
+			SnapshotID:   body.SnapshotID,
+			RangeStart:   body.RangeStart,
+			InsertBefore: body.InsertBefore,
+		})
+
+		status := http.StatusOK
+		if r.Method == http.MethodPost {
+			status = http.StatusCreated
+		}
+
+		return createMockedHttpResponse(t, status, snapshotResponse{SnapshotID: fmt.Sprintf("snapshot-%d", len(*recorded))}), nil
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestAddPlaylistItems(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		uris          []spotifyuri.URI
+		position      int
+		expectedError error
+		want          []recordedRequest
+		wantSnapshot  string
+	}{
+		"id is missing -- should fail": {
+			uris: mockURIs(1),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no items -- should fail": {
+			id: mockID,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid uri -- should fail": {
+			id:   mockID,
+			uris: []spotifyuri.URI{{Kind: spotifyuri.KindTrack, ID: "1234"}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"local file -- should fail": {
+			id:   mockID,
+			uris: []spotifyuri.URI{{Kind: spotifyuri.KindLocal, ID: "Artist:Album:Title:180"}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"album as item -- should fail": {
+			id:   mockID,
+			uris: []spotifyuri.URI{mockURI(spotifyuri.KindAlbum, 1)},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"append items in batches": {
+			id:       mockID,
+			uris:     mockURIs(150),
+			position: -1,
+			want: []recordedRequest{
+				{Method: http.MethodPost, URIs: 100},
+				{Method: http.MethodPost, URIs: 50},
+			},
+			wantSnapshot: "snapshot-2",
+		},
+		"insert items in batches at a position": {
+			id:       mockID,
+			uris:     mockURIs(250),
+			position: 5,
+			want: []recordedRequest{
+				{Method: http.MethodPost, URIs: 100, Position: intPtr(5)},
+				{Method: http.MethodPost, URIs: 100, Position: intPtr(105)},
+				{Method: http.MethodPost, URIs: 50, Position: intPtr(205)},
+			},
+			wantSnapshot: "snapshot-3",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var recorded []recordedRequest
+			c := mockAuthorizedClient(Client{httpClient: mockPlaylistItemsApi(t, &recorded)})
+
+			snapshot, err := c.AddPlaylistItems(context.Background(), tc.id, tc.uris, tc.position)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if snapshot != tc.wantSnapshot {
+				t.Errorf("spotify.Client.AddPlaylistItems() snapshot mismatch, \n - got: '%s', \n - want: '%s'", snapshot, tc.wantSnapshot)
+			}
+
+			if diff := cmp.Diff(tc.want, recorded); diff != "" {
+				t.Errorf("spotify.Client.AddPlaylistItems() requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRemovePlaylistItems(t *testing.T) {
+	var recorded []recordedRequest
+	c := mockAuthorizedClient(Client{httpClient: mockPlaylistItemsApi(t, &recorded)})
+
+	snapshot, err := c.RemovePlaylistItems(context.Background(), mockID, mockURIs(120), "initial")
+	if err != nil {
+		t.Fatalf("spotify.Client.RemovePlaylistItems() unexpected error '%s'", err.Error())
+	}
+
+	want := []recordedRequest{
+		{Method: http.MethodDelete, Tracks: 100, SnapshotID: "initial"},
+		{Method: http.MethodDelete, Tracks: 20, SnapshotID: "snapshot-1"},
+	}
+
+	if diff := cmp.Diff(want, recorded); diff != "" {
+		t.Errorf("spotify.Client.RemovePlaylistItems() requests mismatch (-want +got):\n%s", diff)
+	}
+
+	if snapshot != "snapshot-2" {
+		t.Errorf("spotify.Client.RemovePlaylistItems() unexpected snapshot '%s'", snapshot)
+	}
+}
+
+func TestRemovePlaylistItemsFailed(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: &mockHttpClient{expectedError: errMock}})
+
+	snapshot, err := c.RemovePlaylistItems(context.Background(), mockID, mockURIs(1), "initial")
+	checkSpotifyError(t, errSpotify{code: requestFailed}, err)
+
+	if snapshot != "" {
+		t.Errorf("spotify.Client.RemovePlaylistItems() expected no snapshot, got '%s'", snapshot)
+	}
+}
+
+func TestRemoveLocalPlaylistItems(t *testing.T) {
+	var recorded []recordedRequest
+	c := mockAuthorizedClient(Client{httpClient: mockPlaylistItemsApi(t, &recorded)})
+
+	local, err := spotifyuri.Parse("spotify:local:Artist:Album:Title:180")
+	if err != nil {
+		t.Fatalf("failed to parse local uri, %s", err.Error())
+	}
+
+	_, err = c.RemovePlaylistItems(context.Background(), mockID, []spotifyuri.URI{local, mockURI(spotifyuri.KindTrack, 1)}, "")
+	if err != nil {
+		t.Fatalf("spotify.Client.RemovePlaylistItems() unexpected error '%s'", err.Error())
+	}
+
+	if len(recorded) != 1 || recorded[0].Tracks != 2 {
+		t.Errorf("spotify.Client.RemovePlaylistItems() unexpected requests '%+v'", recorded)
+	}
+}
+
+func TestReorderPlaylistItems(t *testing.T) {
+	testcases := map[string]struct {
+		payload       ReorderPlaylistItemsPayload
+		expectedError error
+		want          []recordedRequest
+	}{
+		"negative position -- should fail": {
+			payload: ReorderPlaylistItemsPayload{RangeStart: -1},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"move the range": {
+			payload: ReorderPlaylistItemsPayload{RangeStart: 3, InsertBefore: 0, RangeLength: 2},
+			want: []recordedRequest{
+				{Method: http.MethodPut, RangeStart: 3},
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var recorded []recordedRequest
+			c := mockAuthorizedClient(Client{httpClient: mockPlaylistItemsApi(t, &recorded)})
+
+			_, err := c.ReorderPlaylistItems(context.Background(), mockID, tc.payload)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.want, recorded); diff != "" {
+				t.Errorf("spotify.Client.ReorderPlaylistItems() requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplacePlaylistItems(t *testing.T) {
+	testcases := map[string]struct {
+		uris         []spotifyuri.URI
+		want         []recordedRequest
+		wantSnapshot string
+	}{
+		"clear the playlist": {
+			uris: []spotifyuri.URI{},
+			want: []recordedRequest{
+				{Method: http.MethodPut},
+			},
+			wantSnapshot: "snapshot-1",
+		},
+		"replace more than 100 items": {
+			uris: mockURIs(150),
+			want: []recordedRequest{
+				{Method: http.MethodPut, URIs: 100},
+				{Method: http.MethodPost, URIs: 50},
+			},
+			wantSnapshot: "snapshot-2",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			var recorded []recordedRequest
+			c := mockAuthorizedClient(Client{httpClient: mockPlaylistItemsApi(t, &recorded)})
+
+			snapshot, err := c.ReplacePlaylistItems(context.Background(), mockID, tc.uris)
+			if err != nil {
+				t.Fatalf("spotify.Client.ReplacePlaylistItems() unexpected error '%s'", err.Error())
+			}
+
+			if snapshot != tc.wantSnapshot {
+				t.Errorf("spotify.Client.ReplacePlaylistItems() snapshot mismatch, \n - got: '%s', \n - want: '%s'", snapshot, tc.wantSnapshot)
+			}
+
+			if diff := cmp.Diff(tc.want, recorded); diff != "" {
+				t.Errorf("spotify.Client.ReplacePlaylistItems() requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPutPlaylistItemsStatus(t *testing.T) {
+	calls := map[string]func(c *Client) (string, error){
+		"replace": func(c *Client) (string, error) {
+			return c.ReplacePlaylistItems(context.Background(), mockID, mockURIs(2))
+		},
+		"reorder": func(c *Client) (string, error) {
+			return c.ReorderPlaylistItems(context.Background(), mockID, ReorderPlaylistItemsPayload{RangeStart: 1})
+		},
+	}
+
+	testcases := map[string]struct {
+		status        int
+		expectedError error
+	}{
+		"ok": {
+			status: http.StatusOK,
+		},
+		"created": {
+			status: http.StatusCreated,
+		},
+		"accepted -- should fail": {
+			status: http.StatusAccepted,
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+	}
+
+	for callName, call := range calls {
+		for testName, tc := range testcases {
+			t.Run(callName+" "+testName, func(t *testing.T) {
+				httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, tc.status, snapshotResponse{SnapshotID: "snapshot"})}
+				c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+				snapshot, err := call(&c)
+				checkSpotifyError(t, tc.expectedError, err)
+				if err != nil {
+					return
+				}
+
+				if httpClient.gotRequest.Method != http.MethodPut {
+					t.Errorf("unexpected method '%s'", httpClient.gotRequest.Method)
+				}
+
+				if snapshot != "snapshot" {
+					t.Errorf("unexpected snapshot id '%s'", snapshot)
+				}
+			})
+		}
+	}
+}
+
+func TestPlaylistItemJSON(t *testing.T) {
+	data := []byte(`{
+		"href": "https://api.spotify.com/v1/playlists/1234/tracks",
+		"items": [
+			{
+				"added_at": "2022-08-01T12:00:00Z",
+				"added_by": {"id": "user", "type": "user"},
+				"is_local": false,
+				"track": {"id": "track", "name": "mock track", "type": "track", "artists": [{"id": "artist", "name": "mock artist"}]}
+			},
+			{
+				"added_at": "2022-08-02T12:00:00Z",
+				"added_by": {"id": "user", "type": "user"},
+				"is_local": false,
+				"track": {"id": "episode", "name": "mock episode", "type": "episode", "duration_ms": 1000, "show": {"id": "show", "name": "mock show", "publisher": "mock"}}
+			},
+			{
+				"added_at": null,
+				"is_local": true,
+				"track": null
+			}
+		],
+		"limit": 100,
+		"total": 3
+	}`)
+
+	want := PlaylistItemsPage{
+		Href: "https://api.spotify.com/v1/playlists/1234/tracks",
+		Items: []PlaylistItem{
+			{
+				AddedAt: time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC),
+				AddedBy: SimpleUser{ID: "user", Type: "user"},
+				Track: &Track{
+					ID:      "track",
+					Name:    "mock track",
+					Type:    "track",
+					Artists: []SimpleArtist{{ID: "artist", Name: "mock artist"}},
+				},
+			},
+			{
+				AddedAt: time.Date(2022, 8, 2, 12, 0, 0, 0, time.UTC),
+				AddedBy: SimpleUser{ID: "user", Type: "user"},
+				Episode: &Episode{
+					ID:         "episode",
+					Name:       "mock episode",
+					Type:       "episode",
+					DurationMs: 1000,
+					Show:       &SimpleShow{ID: "show", Name: "mock show", Publisher: "mock"},
+				},
+			},
+			{
+				IsLocal: true,
+			},
+		},
+		Pagination: Pagination{Limit: 100, Total: 3},
+	}
+
+	var got PlaylistItemsPage
+	err := json.Unmarshal(data, &got)
+	if err != nil {
+		t.Fatalf("PlaylistItem.UnmarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PlaylistItem.UnmarshalJSON() mismatch (-want +got):\n%s", diff)
+	}
+
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("PlaylistItem.MarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	var again PlaylistItemsPage
+	err = json.Unmarshal(out, &again)
+	if err != nil {
+		t.Fatalf("PlaylistItem.UnmarshalJSON() unexpected error '%s'", err.Error())
+	}
+
+	if diff := cmp.Diff(want, again); diff != "" {
+		t.Errorf("PlaylistItem round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPlaylistItems(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		opts          PlaylistItemsOptions
+		expectedError error
+		expectedURL   string
+	}{
+		"id is missing -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"negative offset -- should fail": {
+			id:   mockID,
+			opts: PlaylistItemsOptions{PageOptions: PageOptions{Offset: -1}},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"all options are sent": {
+			id: mockID,
+			opts: PlaylistItemsOptions{
+				Fields:      "items(track(name)),next",
+				Market:      "DE",
+				PageOptions: PageOptions{Limit: 50, Offset: 100},
+			},
+			expectedURL: baseURL + "/playlists/" + mockID + "/tracks?additional_types=track%2Cepisode&fields=items%28track%28name%29%29%2Cnext&limit=50&market=DE&offset=100",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusOK, PlaylistItemsPage{}),
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			_, err := c.GetPlaylistItems(context.Background(), tc.id, tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if got := httpClient.gotRequest.URL.String(); got != tc.expectedURL {
+				t.Errorf("spotify.Client.GetPlaylistItems() url mismatch, \n - got: '%s', \n - want: '%s'", got, tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestPaginatePlaylistItems(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		page := PlaylistItemsPage{
+			Items:      []PlaylistItem{{Track: &Track{ID: r.URL.Query().Get("offset")}}},
+			Pagination: Pagination{Limit: 1, Offset: 1, Total: 2},
+		}
+		if r.URL.Query().Get("offset") == "" {
+			page.Offset = 0
+			page.Next = baseURL + "/playlists/" + mockID + "/tracks?offset=1&limit=1"
+		}
+		return createMockedHttpResponse(t, http.StatusOK, page), nil
+	})})
+
+	var items []PlaylistItem
+	err := c.PaginatePlaylistItems(context.Background(), mockID, PlaylistItemsOptions{PageOptions: PageOptions{Limit: 1}}).Collect(&items)
+	if err != nil {
+		t.Fatalf("spotify.Client.PaginatePlaylistItems() unexpected error '%s'", err.Error())
+	}
+
+	if len(items) != 2 || items[1].Track.ID != "1" {
+		t.Errorf("spotify.Client.PaginatePlaylistItems() unexpected items '%+v'", items)
+	}
+}
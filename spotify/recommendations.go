@@ -0,0 +1,319 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+const (
+	maxRecommendationSeeds = 5
+	maxRecommendations     = 100
+)
+
+// TunableAttribute is an audio attribute the recommendations can be tuned with,
+// see AudioFeatures for the meaning of the values.
+type TunableAttribute string
+
+const (
+	AttributeAcousticness     TunableAttribute = "acousticness"
+	AttributeDanceability     TunableAttribute = "danceability"
+	AttributeDurationMs       TunableAttribute = "duration_ms"
+	AttributeEnergy           TunableAttribute = "energy"
+	AttributeInstrumentalness TunableAttribute = "instrumentalness"
+	AttributeKey              TunableAttribute = "key"
+	AttributeLiveness         TunableAttribute = "liveness"
+	AttributeLoudness         TunableAttribute = "loudness"
+	AttributeMode             TunableAttribute = "mode"
+	AttributePopularity       TunableAttribute = "popularity"
+	AttributeSpeechiness      TunableAttribute = "speechiness"
+	AttributeTempo            TunableAttribute = "tempo"
+	AttributeTimeSignature    TunableAttribute = "time_signature"
+	AttributeValence          TunableAttribute = "valence"
+)
+
+// attributeRange is the valid range of the values of an attribute.
+type attributeRange struct {
+	min, max float64
+	integer  bool
+}
+
+var attributeRanges = map[TunableAttribute]attributeRange{
+	AttributeAcousticness:     {min: 0, max: 1},
+	AttributeDanceability:     {min: 0, max: 1},
+	AttributeDurationMs:       {min: 0, max: math.MaxInt32, integer: true},
+	AttributeEnergy:           {min: 0, max: 1},
+	AttributeInstrumentalness: {min: 0, max: 1},
+	AttributeKey:              {min: 0, max: 11, integer: true},
+	AttributeLiveness:         {min: 0, max: 1},
+	AttributeLoudness:         {min: -60, max: 0},
+	AttributeMode:             {min: 0, max: 1, integer: true},
+	AttributePopularity:       {min: 0, max: 100, integer: true},
+	AttributeSpeechiness:      {min: 0, max: 1},
+	AttributeTempo:            {min: 0, max: 1000},
+	AttributeTimeSignature:    {min: 3, max: 7, integer: true},
+	AttributeValence:          {min: 0, max: 1},
+}
+
+// attributeValues are the tuned values of a single attribute, nil values are not set.
+type attributeValues struct {
+	min, max, target *float64
+}
+
+// RecommendationsQuery is building the parameters of a recommendations request described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-recommendations
+//
+//	q := spotify.NewRecommendationsQuery().
+//		SeedTracks("4uLU6hMCjMI75M1A2tKUQC").
+//		SeedGenres("synthwave").
+//		Min(spotify.AttributeTempo, 110).
+//		Target(spotify.AttributeValence, 0.8)
+//
+// At least one and at most five seeds are required in total.
+type RecommendationsQuery struct {
+	artists []string
+	tracks  []string
+	genres  []string
+	attrs   map[TunableAttribute]*attributeValues
+	limit   int
+	market  string
+	err     error
+}
+
+// NewRecommendationsQuery starts an empty query.
+func NewRecommendationsQuery() *RecommendationsQuery {
+	return &RecommendationsQuery{attrs: map[TunableAttribute]*attributeValues{}}
+}
+
+func (q *RecommendationsQuery) fail(err error) *RecommendationsQuery {
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// seedIDs adds ids of the given kind as seeds.
+func (q *RecommendationsQuery) seedIDs(kind spotifyuri.Kind, dst *[]string, ids []spotifyuri.ID) *RecommendationsQuery {
+	for _, id := range ids {
+		err := validateID(kind, id)
+		if err != nil {
+			return q.fail(err)
+		}
+	}
+	return q.seed(string(kind), dst, idStrings(ids))
+}
+
+func (q *RecommendationsQuery) seed(kind string, dst *[]string, values []string) *RecommendationsQuery {
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			return q.fail(newError(invalidInputs, kind+" seeds can not be empty", nil))
+		}
+		*dst = append(*dst, v)
+	}
+	return q
+}
+
+// SeedArtists adds artist ids as seeds.
+func (q *RecommendationsQuery) SeedArtists(ids ...spotifyuri.ID) *RecommendationsQuery {
+	return q.seedIDs(spotifyuri.KindArtist, &q.artists, ids)
+}
+
+// SeedTracks adds track ids as seeds.
+func (q *RecommendationsQuery) SeedTracks(ids ...spotifyuri.ID) *RecommendationsQuery {
+	return q.seedIDs(spotifyuri.KindTrack, &q.tracks, ids)
+}
+
+// SeedGenres adds genres as seeds, see GetAvailableGenreSeeds for the known genres.
+func (q *RecommendationsQuery) SeedGenres(genres ...string) *RecommendationsQuery {
+	return q.seed("genre", &q.genres, genres)
+}
+
+func (q *RecommendationsQuery) tune(attr TunableAttribute, kind string, v float64) *RecommendationsQuery {
+	r, ok := attributeRanges[attr]
+	if !ok {
+		return q.fail(newError(invalidInputs, "unknown attribute '"+string(attr)+"'", nil))
+	}
+
+	if math.IsNaN(v) || v < r.min || v > r.max {
+		return q.fail(newError(invalidInputs, fmt.Sprintf("%s_%s has to be between %g and %g, got %g", kind, attr, r.min, r.max, v), nil))
+	}
+
+	if r.integer && v != math.Trunc(v) {
+		return q.fail(newError(invalidInputs, fmt.Sprintf("%s_%s has to be an integer, got %g", kind, attr, v), nil))
+	}
+
+	// the map is created lazily, so a zero value query can be used as well:
+	if q.attrs == nil {
+		q.attrs = map[TunableAttribute]*attributeValues{}
+	}
+
+	values, ok := q.attrs[attr]
+	if !ok {
+		values = &attributeValues{}
+		q.attrs[attr] = values
+	}
+
+	switch kind {
+	case "min":
+		values.min = &v
+	case "max":
+		values.max = &v
+	default:
+		values.target = &v
+	}
+
+	return q
+}
+
+// Min only returns tracks with at least the given value of the attribute.
+func (q *RecommendationsQuery) Min(attr TunableAttribute, v float64) *RecommendationsQuery {
+	return q.tune(attr, "min", v)
+}
+
+// Max only returns tracks with at most the given value of the attribute.
+func (q *RecommendationsQuery) Max(attr TunableAttribute, v float64) *RecommendationsQuery {
+	return q.tune(attr, "max", v)
+}
+
+// Target prefers tracks with values of the attribute close to the given one.
+func (q *RecommendationsQuery) Target(attr TunableAttribute, v float64) *RecommendationsQuery {
+	return q.tune(attr, "target", v)
+}
+
+// Limit sets the number of recommended tracks, between 1 and 100. Spotify defaults to 20.
+func (q *RecommendationsQuery) Limit(limit int) *RecommendationsQuery {
+	if limit < 1 || limit > maxRecommendations {
+		return q.fail(newError(invalidInputs, fmt.Sprintf("limit has to be between 1 and %d, got %d", maxRecommendations, limit), nil))
+	}
+
+	q.limit = limit
+	return q
+}
+
+// Market only returns tracks playable in the given market, an ISO 3166-1 alpha-2 country code.
+func (q *RecommendationsQuery) Market(market string) *RecommendationsQuery {
+	err := validateMarket(market)
+	if err != nil {
+		return q.fail(err)
+	}
+
+	q.market = market
+	return q
+}
+
+// values returns the query parameters or the first error of the builder.
+func (q *RecommendationsQuery) values() (url.Values, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	seeds := len(q.artists) + len(q.tracks) + len(q.genres)
+	if seeds == 0 || seeds > maxRecommendationSeeds {
+		return nil, newError(invalidInputs, fmt.Sprintf("between 1 and %d seeds are required, got %d", maxRecommendationSeeds, seeds), nil)
+	}
+
+	v := url.Values{}
+	if len(q.artists) > 0 {
+		v.Set("seed_artists", strings.Join(q.artists, ","))
+	}
+	if len(q.tracks) > 0 {
+		v.Set("seed_tracks", strings.Join(q.tracks, ","))
+	}
+	if len(q.genres) > 0 {
+		v.Set("seed_genres", strings.Join(q.genres, ","))
+	}
+
+	for attr, a := range q.attrs {
+		if a.min != nil && a.max != nil && *a.min > *a.max {
+			return nil, newError(invalidInputs, fmt.Sprintf("min_%s can not be greater than max_%s", attr, attr), nil)
+		}
+
+		if a.target != nil && ((a.min != nil && *a.target < *a.min) || (a.max != nil && *a.target > *a.max)) {
+			return nil, newError(invalidInputs, fmt.Sprintf("target_%s has to be between min_%s and max_%s", attr, attr, attr), nil)
+		}
+
+		for kind, value := range map[string]*float64{"min": a.min, "max": a.max, "target": a.target} {
+			if value != nil {
+				v.Set(kind+"_"+string(attr), strconv.FormatFloat(*value, 'f', -1, 64))
+			}
+		}
+	}
+
+	if q.limit > 0 {
+		v.Set("limit", strconv.Itoa(q.limit))
+	}
+	if q.market != "" {
+		v.Set("market", q.market)
+	}
+
+	return v, nil
+}
+
+// RecommendationSeed describes how a seed was used to generate the recommendations.
+type RecommendationSeed struct {
+	AfterFilteringSize int    `json:"afterFilteringSize"`
+	AfterRelinkingSize int    `json:"afterRelinkingSize"`
+	Href               string `json:"href"`
+	ID                 string `json:"id"`
+	InitialPoolSize    int    `json:"initialPoolSize"`
+	Type               string `json:"type"`
+}
+
+// Recommendations are the tracks recommended for the seeds of a query.
+type Recommendations struct {
+	Seeds  []RecommendationSeed `json:"seeds"`
+	Tracks []Track              `json:"tracks"`
+}
+
+// URIs returns the uris of the recommended tracks, e.g. to add them to a playlist with AddPlaylistItems.
+func (r Recommendations) URIs() []spotifyuri.URI {
+	uris := make([]spotifyuri.URI, 0, len(r.Tracks))
+	for _, t := range r.Tracks {
+		uris = append(uris, spotifyuri.URI{Kind: spotifyuri.KindTrack, ID: spotifyuri.ID(t.ID)})
+	}
+	return uris
+}
+
+// GetRecommendations returns tracks generated from the seeds of the query as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-recommendations
+func (c *Client) GetRecommendations(ctx context.Context, q *RecommendationsQuery) (Recommendations, error) {
+	if q == nil {
+		return Recommendations{}, newError(invalidInputs, "query is required", nil)
+	}
+
+	v, err := q.values()
+	if err != nil {
+		return Recommendations{}, err
+	}
+
+	if v.Get("market") == "" && c.market != "" {
+		v.Set("market", c.market)
+	}
+
+	var r Recommendations
+	err = c.get(ctx, endpoint("recommendations")+"?"+v.Encode(), &r)
+	if err != nil {
+		return Recommendations{}, err
+	}
+
+	return r, nil
+}
+
+// GetAvailableGenreSeeds returns the genres that can be used as seeds as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-recommendation-genres
+func (c *Client) GetAvailableGenreSeeds(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Genres []string `json:"genres"`
+	}
+	err := c.get(ctx, endpoint("recommendations", "available-genre-seeds"), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Genres, nil
+}
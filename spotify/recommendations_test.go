@@ -0,0 +1,126 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestRecommendationsQuery(t *testing.T) {
+	testcases := map[string]struct {
+		query         *RecommendationsQuery
+		want          string
+		expectedError error
+	}{
+		"no seeds -- should fail": {
+			query: NewRecommendationsQuery(),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"too many seeds -- should fail": {
+			query: NewRecommendationsQuery().SeedArtists(mockIDs(2)...).SeedTracks(mockIDs(2)...).SeedGenres("rock", "pop"),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"empty seed -- should fail": {
+			query: NewRecommendationsQuery().SeedTracks(""),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid seed id -- should fail": {
+			query: NewRecommendationsQuery().SeedTracks("t1"),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"value out of range -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Target(AttributeEnergy, 1.5),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"integer attribute with fraction -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Target(AttributeKey, 2.5),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"unknown attribute -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Min("mock", 1),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"min greater than max -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Min(AttributeTempo, 130).Max(AttributeTempo, 120),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"target outside of min and max -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Min(AttributeTempo, 100).Target(AttributeTempo, 90),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid limit -- should fail": {
+			query: NewRecommendationsQuery().SeedGenres("rock").Limit(101),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"zero value query": {
+			query: (&RecommendationsQuery{}).SeedGenres("rock").Min(AttributeEnergy, 0.5),
+			want:  "min_energy=0.5&seed_genres=rock",
+		},
+		"mixed seeds and attributes": {
+			query: NewRecommendationsQuery().
+				SeedArtists(mockID).
+				SeedTracks(mockIDs(2)...).
+				SeedGenres("rock", "pop").
+				Min(AttributeTempo, 110).
+				Max(AttributeTempo, 130.5).
+				Target(AttributeValence, 0.8).
+				Target(AttributePopularity, 70).
+				Limit(50).
+				Market("DE"),
+			want: "limit=50&market=DE&max_tempo=130.5&min_tempo=110&seed_artists=6rqhFgbbKwnb9MLmUQDhG6&seed_genres=rock%2Cpop&seed_tracks=id00000000000000000000%2Cid00000000000000000001&target_popularity=70&target_valence=0.8",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := tc.query.values()
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if err == nil && got.Encode() != tc.want {
+				t.Errorf("RecommendationsQuery.values() mismatch, \n - got: '%s', \n - want: '%s'", got.Encode(), tc.want)
+			}
+		})
+	}
+}
+
+func TestGetRecommendations(t *testing.T) {
+	httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, Recommendations{
+		Tracks: []Track{{ID: "4uLU6hMCjMI75M1A2tKUQC"}, {ID: "6rqhFgbbKwnb9MLmUQDhG6"}},
+	})}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+	got, err := c.GetRecommendations(context.Background(), NewRecommendationsQuery().SeedTracks(mockID))
+	if err != nil {
+		t.Fatalf("spotify.Client.GetRecommendations() unexpected error '%s'", err.Error())
+	}
+
+	want := baseURL + "/recommendations?seed_tracks=" + mockID
+	if httpClient.gotRequest.URL.String() != want {
+		t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), want)
+	}
+
+	uris := got.URIs()
+	if len(uris) != 2 || uris[1].String() != "spotify:track:6rqhFgbbKwnb9MLmUQDhG6" {
+		t.Errorf("spotify.Recommendations.URIs() unexpected uris '%v'", uris)
+	}
+}
@@ -2,39 +2,227 @@ package spotify
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
-func (c *Client) createAuthorizedRequest(method, url string, body []byte) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+// endpoint returns the url of an endpoint of the api, every path segment is escaped,
+// so e.g. user ids with special characters are not breaking the url.
+func endpoint(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = url.PathEscape(s)
+	}
+
+	return baseURL + "/" + strings.Join(escaped, "/")
+}
+
+func (c *Client) createAuthorizedRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	t, err := c.validToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.resolve(url), bytes.NewReader(body))
 	if err != nil {
 		return req, err
 	}
 
-	req.Header.Add("Authorization", "Bearer "+c.token)
+	req.Header.Add("Authorization", "Bearer "+t.AccessToken)
 	req.Header.Add("Accept", "application/json")
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage())
+	}
 
 	return req, nil
 }
 
-func (c *Client) doRequest(req *http.Request, expectedStatus int) (*http.Response, error) {
+// get requests the given url of the api and decodes the response into v.
+func (c *Client) get(ctx context.Context, url string, v interface{}) error {
+	return c.send(ctx, http.MethodGet, url, nil, http.StatusOK, v)
+}
+
+// send is the common way of talking to the api. The payload is sent as json body if it is not nil
+// and the response is decoded into v if it is not nil.
+func (c *Client) send(ctx context.Context, method, url string, payload interface{}, expectedStatus int, v interface{}) error {
+	if payload == nil {
+		return c.sendRaw(ctx, method, url, "", nil, expectedStatus, v)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return newError(internalError, "failed to marshal request payload", err)
+	}
+
+	return c.sendRaw(ctx, method, url, "application/json", body, expectedStatus, v)
+}
+
+// getOptional is like get, but the endpoint is answering with 204 if there is nothing to return.
+// v is left untouched in that case.
+func (c *Client) getOptional(ctx context.Context, url string, v interface{}) error {
+	return c.sendAccepting(ctx, http.MethodGet, url, "", nil, []int{http.StatusOK, http.StatusNoContent}, v)
+}
+
+// sendRaw is like send, but the body is sent as it is with the given content type,
+// e.g. for images that are not sent as json.
+func (c *Client) sendRaw(ctx context.Context, method, url, contentType string, body []byte, expectedStatus int, v interface{}) error {
+	return c.sendAccepting(ctx, method, url, contentType, body, []int{expectedStatus}, v)
+}
+
+// sendAccepting is like sendRaw, but any of the given statuses is a success.
+// The first one is the status that is usually expected.
+func (c *Client) sendAccepting(ctx context.Context, method, url, contentType string, body []byte, statuses []int, v interface{}) error {
+	if !c.IsAuthorized() {
+		return newError(notAuthorized, "client is not authorized", nil)
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	req, err := c.createAuthorizedRequest(ctx, method, url, body)
+	if err != nil {
+		return newError(internalError, "failed to create authorized request", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.doRequest(req, statuses[0], statuses[1:]...)
+	if err != nil {
+		return requestError(err)
+	}
+	defer resp.Body.Close()
+
+	// there is nothing to decode, e.g. the playback state if nothing is playing:
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return newError(internalError, "failed to decode api response", err)
+	}
+
+	return nil
+}
+
+// doRequest sends the request and returns the response if its status is the expected one
+// or one of alsoAccepted.
+func (c *Client) doRequest(req *http.Request, expectedStatus int, alsoAccepted ...int) (*http.Response, error) {
 	if req == nil {
 		// this can only happen in a internal use case of this pkg, so specify the method name:
 		return nil, errors.New("doRequest(): the request as input can not be nil")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			// we return only the error here, since we can ignore the resp according to the docs:
+			// https://pkg.go.dev/net/http#Client.Do
+			return nil, checkCanceled(req.Context(), err)
+		}
+
+		// the token got rejected, e.g. because it expired earlier than expected or got revoked.
+		// Try to refresh it once and replay the request with the new one:
+		if resp.StatusCode == http.StatusUnauthorized && expectedStatus != http.StatusUnauthorized && !refreshed && c.canRefresh() {
+			resp.Body.Close()
+			refreshed = true
+
+			req, err = c.withRefreshedToken(req)
+			if err != nil {
+				return nil, err
+			}
+
+			// a refresh is not counting as an attempt of the retry policy:
+			attempt--
+			continue
+		}
+
+		if !accepted(expectedStatus, alsoAccepted, resp.StatusCode) {
+			wait, retry := c.retry.delay(attempt, req.Method, resp)
+			if retry && canReplay(req) {
+				resp.Body.Close()
+
+				select {
+				case <-req.Context().Done():
+					return nil, checkCanceled(req.Context(), req.Context().Err())
+				case <-timeAfter(wait):
+				}
+
+				req, err = replay(req)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			defer resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status code, got: '%d' - expected: '%d', %w", resp.StatusCode, expectedStatus, parseErrResponse(resp))
+		}
+
+		return resp, nil
+	}
+}
+
+// accepted reports if the status of a response is the expected one or one of the also accepted ones.
+// For endpoints without content, e.g. the player commands, any success is fine.
+func accepted(expected int, also []int, got int) bool {
+	if got == expected || (expected == http.StatusNoContent && got >= 200 && got < 300) {
+		return true
+	}
+
+	for _, status := range also {
+		if got == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canReplay reports if the body of the request can be sent again.
+// Requests created via createAuthorizedRequest are always replayable,
+// since their body is a []byte.
+func canReplay(req *http.Request) bool {
+	return req.GetBody != nil || req.Body == nil || req.Body == http.NoBody
+}
+
+// replay returns a copy of the request with a fresh body to send it again.
+func replay(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return r, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay request body, %w", err)
+	}
+	r.Body = body
+
+	return r, nil
+}
+
+func (c *Client) withRefreshedToken(req *http.Request) (*http.Request, error) {
+	rejected := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+	t, err := c.refreshToken(req.Context(), rejected)
 	if err != nil {
-		// we return only the error here, since we can ignore the resp according to the docs:
-		// https://pkg.go.dev/net/http#Client.Do
 		return nil, err
 	}
 
-	if resp.StatusCode != expectedStatus {
-		return nil, fmt.Errorf("unexpected status code, got: '%d' - expected: '%d', %w", resp.StatusCode, expectedStatus, parseErrResponse(resp))
+	r, err := replay(req)
+	if err != nil {
+		return nil, err
 	}
+	r.Header.Set("Authorization", "Bearer "+t.AccessToken)
 
-	return resp, nil
+	return r, nil
 }
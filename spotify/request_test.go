@@ -19,6 +19,7 @@ func TestDoRequest(t *testing.T) {
 	expectedStatusErr := errResponse{Err: struct {
 		Status  int    "json:\"status\""
 		Message string "json:\"message\""
+		Reason  string "json:\"reason,omitempty\""
 	}{
 		Status:  http.StatusBadRequest,
 		Message: "something failed",
@@ -0,0 +1,119 @@
+package spotify
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is configuring how a client is retrying requests that failed temporarily.
+// Requests are retried if spotify is rate limiting the client (429) or on transient server
+// errors (500, 502, 503, 504). Server errors are only retried for idempotent methods,
+// since e.g. a POST that adds items to a playlist may have been applied already.
+// The rate limiting is described here:
+// https://developer.spotify.com/documentation/web-api/guides/rate-limits/
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request including the first one.
+	// A value of 0 or 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry of a server error,
+	// it is doubled with every further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff. If spotify is asking via the Retry-After header
+	// to wait longer than that, the request fails right away with the rate limit error
+	// instead of blocking the caller for that long.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the policy used by clients created via NewClient or NewAuthorizedClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// timeAfter is a variable to be able to skip the waiting in tests.
+var timeAfter = time.After
+
+// SetRetryPolicy replaces the retry policy of the client.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// delay returns how long to wait before the next attempt and if the request should be retried at all.
+func (p RetryPolicy) delay(attempt int, method string, resp *http.Response) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		d, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if !ok {
+			return p.backoff(attempt), true
+		}
+
+		if p.MaxDelay > 0 && d > p.MaxDelay {
+			return 0, false
+		}
+
+		return d, true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotent(method) {
+			return 0, false
+		}
+
+		return p.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// idempotent reports if a request with the given method can be sent again without changing the result,
+// in contrast to a 429 a server error does not tell if the request was applied.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// backoff is an exponential backoff with jitter, so clients that failed at the same time
+// are not hitting the api again at the same time.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter supports both formats of the header, spotify is sending the delay in seconds.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(timeNow())
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,187 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func mockTimeAfter(t *testing.T) {
+	timeAfter = func(time.Duration) <-chan time.Time {
+		c := make(chan time.Time, 1)
+		c <- time.Time{}
+		return c
+	}
+	t.Cleanup(func() { timeAfter = time.After })
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Second,
+	}
+
+	testcases := map[string]struct {
+		attempt    int
+		method     string
+		status     int
+		retryAfter string
+		wantRetry  bool
+		wantMin    time.Duration
+		wantMax    time.Duration
+	}{
+		"client error -- no retry": {
+			attempt: 1,
+			status:  http.StatusBadRequest,
+		},
+		"max attempts reached -- no retry": {
+			attempt: 3,
+			status:  http.StatusServiceUnavailable,
+		},
+		"rate limited -- wait as long as requested": {
+			attempt:    1,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "3",
+			wantRetry:  true,
+			wantMin:    3 * time.Second,
+			wantMax:    3 * time.Second,
+		},
+		"rate limited longer than max delay -- no retry": {
+			attempt:    1,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "60",
+		},
+		"server error of a post -- no retry": {
+			attempt: 1,
+			method:  http.MethodPost,
+			status:  http.StatusBadGateway,
+		},
+		"rate limited post -- retry": {
+			attempt:    1,
+			method:     http.MethodPost,
+			status:     http.StatusTooManyRequests,
+			retryAfter: "1",
+			wantRetry:  true,
+			wantMin:    time.Second,
+			wantMax:    time.Second,
+		},
+		"server error -- exponential backoff with jitter": {
+			attempt:   2,
+			status:    http.StatusBadGateway,
+			wantRetry: true,
+			wantMin:   time.Second,
+			wantMax:   2 * time.Second,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+			if tc.retryAfter != "" {
+				resp.Header.Set("Retry-After", tc.retryAfter)
+			}
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			got, retry := policy.delay(tc.attempt, method, resp)
+			if retry != tc.wantRetry {
+				t.Fatalf("RetryPolicy.delay() retry mismatch, \n - got: '%v', \n - want: '%v'", retry, tc.wantRetry)
+			}
+
+			if got < tc.wantMin || got > tc.wantMax {
+				t.Errorf("RetryPolicy.delay() delay '%s' is not between '%s' and '%s'", got, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+func TestDoRequestRetries(t *testing.T) {
+	mockTimeAfter(t)
+
+	testcases := map[string]struct {
+		method        string
+		statuses      []int
+		policy        RetryPolicy
+		wantAttempts  int
+		expectedError bool
+	}{
+		"no retry policy -- fail on first error": {
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusCreated},
+			wantAttempts:  1,
+			expectedError: true,
+		},
+		"transient errors -- succeed after retries": {
+			method:       http.MethodPut,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusCreated},
+			policy:       DefaultRetryPolicy,
+			wantAttempts: 3,
+		},
+		"server error of a post -- fail without retry": {
+			statuses:      []int{http.StatusBadGateway, http.StatusCreated},
+			policy:        DefaultRetryPolicy,
+			wantAttempts:  1,
+			expectedError: true,
+		},
+		"rate limited post -- succeed after retry": {
+			statuses:     []int{http.StatusTooManyRequests, http.StatusCreated},
+			policy:       DefaultRetryPolicy,
+			wantAttempts: 2,
+		},
+		"errors exceed max attempts -- fail": {
+			method:        http.MethodDelete,
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusCreated},
+			policy:        RetryPolicy{MaxAttempts: 2},
+			wantAttempts:  2,
+			expectedError: true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			attempts := 0
+			c := mockAuthorizedClient(Client{
+				httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(r.Body)
+					if string(body) != `{"name":"mock"}` {
+						t.Errorf("attempt '%d' was sent with unexpected body '%s'", attempts, body)
+					}
+
+					status := tc.statuses[attempts]
+					attempts++
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Retry-After": {"1"}},
+						Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+					}, nil
+				}),
+				retry: tc.policy,
+			})
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			req, err := c.createAuthorizedRequest(context.Background(), method, baseURL, []byte(`{"name":"mock"}`))
+			if err != nil {
+				t.Fatalf("failed to create request, %s", err.Error())
+			}
+
+			_, err = c.doRequest(req, http.StatusCreated)
+			if (err != nil) != tc.expectedError {
+				t.Errorf("spotify.Client.doRequest() error mismatch, got: '%v'", err)
+			}
+
+			if attempts != tc.wantAttempts {
+				t.Errorf("spotify.Client.doRequest() attempts mismatch, \n - got: '%d', \n - want: '%d'", attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
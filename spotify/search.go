@@ -0,0 +1,261 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SearchType is a type of object that can be searched for.
+type SearchType string
+
+const (
+	SearchTypeAlbum     SearchType = "album"
+	SearchTypeArtist    SearchType = "artist"
+	SearchTypePlaylist  SearchType = "playlist"
+	SearchTypeTrack     SearchType = "track"
+	SearchTypeShow      SearchType = "show"
+	SearchTypeEpisode   SearchType = "episode"
+	SearchTypeAudiobook SearchType = "audiobook"
+)
+
+func (t SearchType) valid() bool {
+	switch t {
+	case SearchTypeAlbum, SearchTypeArtist, SearchTypePlaylist, SearchTypeTrack, SearchTypeShow, SearchTypeEpisode, SearchTypeAudiobook:
+		return true
+	}
+
+	return false
+}
+
+// SearchQuery is building the query of a search, including the field filters described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/search
+//
+//	q, err := spotify.NewSearchQuery("bohemian rhapsody").Artist("Queen").YearRange(1975, 1980).Build()
+type SearchQuery struct {
+	text    string
+	filters []string
+	err     error
+}
+
+// NewSearchQuery starts a query with the given free text, which can be empty if only filters are used.
+func NewSearchQuery(text string) *SearchQuery {
+	return &SearchQuery{text: strings.TrimSpace(text)}
+}
+
+// quoteFilterValue quotes values containing whitespace, so they are matched as a whole.
+// Spotify has no way of escaping double quotes inside a quoted value, so they are removed.
+func quoteFilterValue(v string) string {
+	v = strings.Join(strings.Fields(strings.ReplaceAll(v, `"`, " ")), " ")
+	if strings.ContainsAny(v, " :") {
+		return `"` + v + `"`
+	}
+
+	return v
+}
+
+func (q *SearchQuery) filter(field, value string) *SearchQuery {
+	value = quoteFilterValue(value)
+	if value == "" {
+		if q.err == nil {
+			q.err = newError(invalidInputs, "value of the filter '"+field+"' can not be empty", nil)
+		}
+		return q
+	}
+
+	q.filters = append(q.filters, field+":"+value)
+
+	return q
+}
+
+// Album filters by the name of the album, for albums and tracks.
+func (q *SearchQuery) Album(name string) *SearchQuery {
+	return q.filter("album", name)
+}
+
+// Artist filters by the name of the artist, for albums, artists and tracks.
+func (q *SearchQuery) Artist(name string) *SearchQuery {
+	return q.filter("artist", name)
+}
+
+// Track filters by the name of the track.
+func (q *SearchQuery) Track(name string) *SearchQuery {
+	return q.filter("track", name)
+}
+
+// Genre filters artists and tracks by a genre.
+func (q *SearchQuery) Genre(genre string) *SearchQuery {
+	return q.filter("genre", genre)
+}
+
+// ISRC filters tracks by their International Standard Recording Code.
+func (q *SearchQuery) ISRC(isrc string) *SearchQuery {
+	return q.filter("isrc", isrc)
+}
+
+// UPC filters albums by their Universal Product Code.
+func (q *SearchQuery) UPC(upc string) *SearchQuery {
+	return q.filter("upc", upc)
+}
+
+// Year filters albums, artists and tracks by the year of release.
+func (q *SearchQuery) Year(year int) *SearchQuery {
+	if year <= 0 {
+		if q.err == nil {
+			q.err = newError(invalidInputs, "year has to be positive", nil)
+		}
+		return q
+	}
+
+	return q.filter("year", fmt.Sprint(year))
+}
+
+// YearRange filters albums, artists and tracks by a range of release years, including both years.
+func (q *SearchQuery) YearRange(from, to int) *SearchQuery {
+	if from <= 0 || to < from {
+		if q.err == nil {
+			q.err = newError(invalidInputs, fmt.Sprintf("invalid year range '%d-%d'", from, to), nil)
+		}
+		return q
+	}
+
+	return q.filter("year", fmt.Sprintf("%d-%d", from, to))
+}
+
+// TagNew only returns albums released in the past two weeks.
+func (q *SearchQuery) TagNew() *SearchQuery {
+	return q.filter("tag", "new")
+}
+
+// TagHipster only returns albums with the lowest 10% popularity.
+func (q *SearchQuery) TagHipster() *SearchQuery {
+	return q.filter("tag", "hipster")
+}
+
+// Build returns the query or the first error of an invalid filter.
+func (q *SearchQuery) Build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+
+	parts := q.filters
+	if q.text != "" {
+		parts = append([]string{q.text}, parts...)
+	}
+
+	if len(parts) == 0 {
+		return "", newError(invalidInputs, "search query can not be empty", nil)
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// String returns the query, invalid filters are ignored. Use Build to check for them.
+func (q *SearchQuery) String() string {
+	parts := q.filters
+	if q.text != "" {
+		parts = append([]string{q.text}, parts...)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// SearchOptions are the optional parameters of a search.
+type SearchOptions struct {
+	// Market is an ISO 3166-1 alpha-2 country code, only content available in this market is returned.
+	Market string
+	// IncludeExternalAudio marks externally hosted audio content as relevant for the search.
+	IncludeExternalAudio bool
+	// PageOptions are applied to every type that is searched for.
+	PageOptions
+}
+
+// SearchResult holds a page for every type that was searched for, the other pages are nil.
+type SearchResult struct {
+	Albums     *SimpleAlbumPage     `json:"albums,omitempty"`
+	Artists    *ArtistPage          `json:"artists,omitempty"`
+	Playlists  *SimplePlaylistPage  `json:"playlists,omitempty"`
+	Tracks     *TrackPage           `json:"tracks,omitempty"`
+	Shows      *SimpleShowPage      `json:"shows,omitempty"`
+	Episodes   *EpisodePage         `json:"episodes,omitempty"`
+	Audiobooks *SimpleAudiobookPage `json:"audiobooks,omitempty"`
+}
+
+func searchURL(query string, types []SearchType, opts SearchOptions) (string, error) {
+	if strings.TrimSpace(query) == "" {
+		return "", newError(invalidInputs, "search query can not be empty", nil)
+	}
+
+	if len(types) == 0 {
+		return "", newError(invalidInputs, "at least one search type is required", nil)
+	}
+
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if !t.valid() {
+			return "", newError(invalidInputs, "unknown search type '"+string(t)+"'", nil)
+		}
+		names = append(names, string(t))
+	}
+
+	err := opts.validate()
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{
+		"q":    {query},
+		"type": {strings.Join(names, ",")},
+	}
+	if opts.Market != "" {
+		q.Set("market", opts.Market)
+	}
+	if opts.IncludeExternalAudio {
+		q.Set("include_external", "audio")
+	}
+	opts.apply(q)
+
+	return endpoint("search") + "?" + q.Encode(), nil
+}
+
+// Search is looking up catalog items that match the query as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/search
+// The query can be built with NewSearchQuery.
+func (c *Client) Search(ctx context.Context, query string, types []SearchType, opts SearchOptions) (SearchResult, error) {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	opts.Market = market
+
+	u, err := searchURL(query, types, opts)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	var result SearchResult
+	err = c.get(ctx, u, &result)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	return result, nil
+}
+
+// PaginateSearch returns a paginator over all results of a single type, the pages have to be
+// decoded into the page type of the search type, e.g. *TrackPage for SearchTypeTrack.
+func (c *Client) PaginateSearch(ctx context.Context, query string, t SearchType, opts SearchOptions) *Paginator {
+	market, err := c.marketOr(opts.Market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+	opts.Market = market
+
+	u, err := searchURL(query, []SearchType{t}, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
@@ -0,0 +1,165 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchQuery(t *testing.T) {
+	testcases := map[string]struct {
+		query         *SearchQuery
+		want          string
+		expectedError error
+	}{
+		"empty query -- should fail": {
+			query: NewSearchQuery(" "),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"empty filter value -- should fail": {
+			query: NewSearchQuery("mock").Artist(""),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"invalid year range -- should fail": {
+			query: NewSearchQuery("mock").YearRange(1980, 1975),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"free text only": {
+			query: NewSearchQuery("bohemian rhapsody"),
+			want:  "bohemian rhapsody",
+		},
+		"filters only": {
+			query: NewSearchQuery("").ISRC("GBUM71029604").TagNew(),
+			want:  "isrc:GBUM71029604 tag:new",
+		},
+		"values with whitespace and quotes are quoted": {
+			query: NewSearchQuery("harder better").Artist("Daft Punk").Album(`Discovery "Deluxe"`).Track("one: more time"),
+			want:  `harder better artist:"Daft Punk" album:"Discovery Deluxe" track:"one: more time"`,
+		},
+		"all filters": {
+			query: NewSearchQuery("mock").Genre("rock").Year(1975).YearRange(1970, 1980).UPC("123").TagHipster(),
+			want:  "mock genre:rock year:1975 year:1970-1980 upc:123 tag:hipster",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := tc.query.Build()
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if got != tc.want {
+				t.Errorf("SearchQuery.Build() mismatch, \n - got: '%s', \n - want: '%s'", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	testcases := map[string]struct {
+		query         string
+		types         []SearchType
+		opts          SearchOptions
+		expectedError error
+		expectedURL   string
+	}{
+		"empty query -- should fail": {
+			types: []SearchType{SearchTypeTrack},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"no type -- should fail": {
+			query: "mock",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"unknown type -- should fail": {
+			query: "mock",
+			types: []SearchType{"podcast"},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"all options are sent": {
+			query: `artist:"Daft Punk"`,
+			types: []SearchType{SearchTypeTrack, SearchTypeAlbum},
+			opts: SearchOptions{
+				Market:               "DE",
+				IncludeExternalAudio: true,
+				PageOptions:          PageOptions{Limit: 10},
+			},
+			expectedURL: baseURL + "/search?include_external=audio&limit=10&market=DE&q=artist%3A%22Daft+Punk%22&type=track%2Calbum",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{
+				expectedResponse: createMockedHttpResponse(t, http.StatusOK, SearchResult{
+					Tracks: &TrackPage{Items: []Track{{ID: "track"}}},
+				}),
+			}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			got, err := c.Search(context.Background(), tc.query, tc.types, tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if u := httpClient.gotRequest.URL.String(); u != tc.expectedURL {
+				t.Errorf("spotify.Client.Search() url mismatch, \n - got: '%s', \n - want: '%s'", u, tc.expectedURL)
+			}
+
+			want := SearchResult{Tracks: &TrackPage{Items: []Track{{ID: "track"}}}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("spotify.Client.Search() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPaginateSearch(t *testing.T) {
+	c := mockAuthorizedClient(Client{httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Query().Get("type") != "artist" {
+			t.Errorf("unexpected search type '%s'", r.URL.Query().Get("type"))
+		}
+
+		page := ArtistPage{
+			Items:      []Artist{{ID: r.URL.Query().Get("offset")}},
+			Pagination: Pagination{Limit: 1, Offset: 1, Total: 2},
+		}
+		if r.URL.Query().Get("offset") == "" {
+			page.Offset = 0
+			page.Next = r.URL.String() + "&offset=1"
+		}
+		return createMockedHttpResponse(t, http.StatusOK, SearchResult{Artists: &page}), nil
+	})})
+
+	p := c.PaginateSearch(context.Background(), "mock", SearchTypeArtist, SearchOptions{PageOptions: PageOptions{Limit: 1}})
+
+	var got []string
+	var page ArtistPage
+	for p.Next(&page) {
+		for _, a := range page.Items {
+			got = append(got, a.ID)
+		}
+	}
+
+	if p.Err() != nil {
+		t.Fatalf("spotify.Client.PaginateSearch() unexpected error '%s'", p.Err().Error())
+	}
+
+	if diff := cmp.Diff([]string{"", "1"}, got); diff != "" {
+		t.Errorf("spotify.Client.PaginateSearch() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,145 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// SimpleShow is the simplified show object of a podcast, as it is returned e.g. by a search.
+type SimpleShow struct {
+	AvailableMarkets   []string     `json:"available_markets"`
+	Copyrights         []Copyright  `json:"copyrights"`
+	Description        string       `json:"description"`
+	Explicit           bool         `json:"explicit"`
+	ExternalURLs       ExternalURLs `json:"external_urls"`
+	Href               string       `json:"href"`
+	HTMLDescription    string       `json:"html_description"`
+	ID                 string       `json:"id"`
+	Images             []Image      `json:"images"`
+	IsExternallyHosted bool         `json:"is_externally_hosted"`
+	Languages          []string     `json:"languages"`
+	MediaType          string       `json:"media_type"`
+	Name               string       `json:"name"`
+	Publisher          string       `json:"publisher"`
+	TotalEpisodes      int          `json:"total_episodes"`
+	Type               string       `json:"type"`
+	URI                string       `json:"uri"`
+}
+
+// SimpleShowPage is a page of simplified shows, e.g. of a search.
+type SimpleShowPage struct {
+	Href  string       `json:"href"`
+	Items []SimpleShow `json:"items"`
+	Pagination
+}
+
+// Show is the full show object of a podcast described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-show
+type Show struct {
+	SimpleShow
+	// Episodes is the first page of the episodes, use GetShowEpisodes for the others.
+	Episodes EpisodePage `json:"episodes"`
+}
+
+// maxShowsPerRequest is the maximum number of ids of a single request to the several shows endpoint.
+const maxShowsPerRequest = 50
+
+// GetShow returns the show with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-show
+// The market is optional and defaults to the market of the client, see SetMarket.
+func (c *Client) GetShow(ctx context.Context, id spotifyuri.ID, market string) (Show, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Show{}, err
+	}
+
+	err = validateID(spotifyuri.KindShow, id)
+	if err != nil {
+		return Show{}, err
+	}
+
+	var s Show
+	err = c.get(ctx, endpoint("shows", string(id))+marketQuery(market), &s)
+	if err != nil {
+		return Show{}, err
+	}
+
+	return s, nil
+}
+
+// GetShows returns the shows with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-multiple-shows
+// The shows are in the same order as the ids, unknown ids result in a nil show.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetShows(ctx context.Context, ids []spotifyuri.ID, market string) ([]*SimpleShow, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*SimpleShow, len(ids))
+	err = batch(ctx, spotifyuri.KindShow, ids, maxShowsPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Shows []*SimpleShow `json:"shows"`
+		}
+		err := c.get(ctx, endpoint("shows")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Shows)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func showEpisodesURL(id spotifyuri.ID, market string, opts PageOptions) (string, error) {
+	err := validateID(spotifyuri.KindShow, id)
+	if err != nil {
+		return "", err
+	}
+
+	return pagedURL(endpoint("shows", string(id), "episodes"), market, opts)
+}
+
+// GetShowEpisodes returns a page of the episodes of a show as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-a-shows-episodes
+func (c *Client) GetShowEpisodes(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) (EpisodePage, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return EpisodePage{}, err
+	}
+
+	u, err := showEpisodesURL(id, market, opts)
+	if err != nil {
+		return EpisodePage{}, err
+	}
+
+	var page EpisodePage
+	err = c.get(ctx, u, &page)
+	if err != nil {
+		return EpisodePage{}, err
+	}
+
+	return page, nil
+}
+
+// PaginateShowEpisodes returns a paginator over all episodes of a show, the pages are of type EpisodePage.
+func (c *Client) PaginateShowEpisodes(ctx context.Context, id spotifyuri.ID, market string, opts PageOptions) *Paginator {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	u, err := showEpisodesURL(id, market, opts)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, u, PageOptions{})
+}
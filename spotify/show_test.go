@@ -0,0 +1,84 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestGetShowEpisodes(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		market        string
+		opts          PageOptions
+		expectedURL   string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"negative offset -- should fail": {
+			id:   mockID,
+			opts: PageOptions{Offset: -1},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"market and page": {
+			id:          mockID,
+			market:      "DE",
+			opts:        PageOptions{Limit: 50, Offset: 50},
+			expectedURL: baseURL + "/shows/" + mockID + "/episodes?limit=50&market=DE&offset=50",
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, EpisodePage{})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			_, err := c.GetShowEpisodes(context.Background(), tc.id, tc.market, tc.opts)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if tc.expectedURL != "" && httpClient.gotRequest.URL.String() != tc.expectedURL {
+				t.Errorf("unexpected url, \n - got: '%s', \n - want: '%s'", httpClient.gotRequest.URL.String(), tc.expectedURL)
+			}
+		})
+	}
+}
+
+func TestGetEpisodes(t *testing.T) {
+	var requests []int
+	c := mockAuthorizedClient(Client{httpClient: mockBatchApi(t, "episodes", &requests)})
+
+	ids := mockIDs(3)
+	ids[1] = mockUnknownID
+
+	got, err := c.GetEpisodes(context.Background(), ids, "DE")
+	if err != nil {
+		t.Fatalf("spotify.Client.GetEpisodes() unexpected error '%s'", err.Error())
+	}
+
+	if len(got) != 3 || got[0].ID != string(ids[0]) || got[1] != nil || got[2].ID != string(ids[2]) {
+		t.Errorf("spotify.Client.GetEpisodes() unexpected episodes '%+v'", got)
+	}
+}
+
+func TestSaveShows(t *testing.T) {
+	httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, nil)}
+	c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+	err := c.RemoveSavedShows(context.Background(), mockIDs(2))
+	if err != nil {
+		t.Fatalf("spotify.Client.RemoveSavedShows() unexpected error '%s'", err.Error())
+	}
+
+	want := baseURL + "/me/shows?ids=id00000000000000000000%2Cid00000000000000000001"
+	if httpClient.gotRequest.Method != http.MethodDelete || httpClient.gotRequest.URL.String() != want {
+		t.Errorf("unexpected request '%s %s', want '%s'", httpClient.gotRequest.Method, httpClient.gotRequest.URL.String(), want)
+	}
+}
@@ -1,9 +1,11 @@
 package spotify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const (
@@ -15,7 +17,23 @@ type Client struct {
 	id         string
 	secret     string
 	userName   string
-	token      string
+
+	// auth is a pointer, so copies of a client are sharing the same token
+	// and refresh it only once.
+	auth *tokenSource
+
+	retry RetryPolicy
+
+	// market and locale are the defaults of the calls, see SetMarket and SetLocale.
+	market string
+	locale string
+
+	// apiBaseURL and accountsBaseURL are replacing the urls of spotify if they are set, see New.
+	apiBaseURL      string
+	accountsBaseURL string
+
+	// timeout is the default timeout of a call, it is disabled if zero.
+	timeout time.Duration
 }
 
 // Pagination is the representation of the pagination values that are
@@ -29,73 +47,122 @@ type Pagination struct {
 	Total    int64  `json:"total"`
 }
 
-// Playlist is a minimal representation of the response object described here:
+// Playlist is the representation of the playlist object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-playlist
 // https://developer.spotify.com/documentation/web-api/reference/#/operations/create-playlist
-// It does not aim to be the complete representation for now.
 type Playlist struct {
-	Collaborative bool   `json:"collaborative"`
-	Description   string `json:"description"`
-	Href          string `json:"href"`
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	Public        bool   `json:"public"`
-	SnapshotID    string `json:"snapshot_id"`
-	Type          string `json:"type"`
-	URI           string `json:"uri"`
-	Pagination
+	Collaborative bool         `json:"collaborative"`
+	Description   string       `json:"description"`
+	ExternalURLs  ExternalURLs `json:"external_urls"`
+	Followers     Followers    `json:"followers"`
+	Href          string       `json:"href"`
+	ID            string       `json:"id"`
+	Images        []Image      `json:"images"`
+	Name          string       `json:"name"`
+	Owner         SimpleUser   `json:"owner"`
+	Public        bool         `json:"public"`
+	SnapshotID    string       `json:"snapshot_id"`
+	// Tracks is the first page of the items of the playlist, the remaining ones
+	// are returned by GetPlaylistItems or PaginatePlaylistItems.
+	Tracks PlaylistItemsPage `json:"tracks"`
+	Type   string            `json:"type"`
+	URI    string            `json:"uri"`
 }
 
-// UserPlaylists is the minimal representation of this response
-// // https://developer.spotify.com/documentation/web-api/reference/#/operations/get-list-users-playlists
+// UserPlaylists is the representation of this response
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-list-users-playlists
 type UserPlaylists struct {
-	Href  string        `json:"href"`
-	Items []interface{} `json:"items"`
+	Href  string           `json:"href"`
+	Items []SimplePlaylist `json:"items"`
 	Pagination
 }
 
+// NewClient creates a client for the client credentials flow, see Authorize.
+// The user can be empty, the client is looking up the current user of the token then.
 func NewClient(id, secret, user string) Client {
 	return Client{
 		httpClient: http.DefaultClient,
 		id:         id,
 		secret:     secret,
 		userName:   user,
+		auth:       newTokenSource(Token{}),
+		retry:      DefaultRetryPolicy,
 	}
 }
 
+// NewAuthorizedClient creates a client with an existing access token.
+// The user can be empty, the client is looking up the current user of the token then.
 func NewAuthorizedClient(user, token string) Client {
 	return Client{
 		httpClient: http.DefaultClient,
 		userName:   user,
-		token:      token,
+		auth:       newTokenSource(Token{AccessToken: token}),
+		retry:      DefaultRetryPolicy,
 	}
 }
 
 func (c *Client) Authorize() error {
-	t, err := retrieveAuthToken(c.httpClient, c.id, c.secret)
+	return c.AuthorizeContext(context.Background())
+}
+
+// AuthorizeContext is like Authorize, but the token request is bound to the given context.
+func (c *Client) AuthorizeContext(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	t, err := retrieveAuthToken(ctx, c.httpClient, tokenURL(c.accountsBaseURL), c.id, c.secret)
 	if err != nil {
 		return fmt.Errorf("failed to authorize spotify client, %w", err)
 	}
 
-	c.token = t
+	if c.auth == nil {
+		c.auth = newTokenSource(t)
+		return nil
+	}
+
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	err = c.auth.replaceLocked(t)
+	if err != nil {
+		return newError(internalError, "failed to store token", err)
+	}
 
 	return nil
 }
 
 func (c *Client) IsAuthorized() bool {
-	return c.token != ""
+	return c.currentToken().AccessToken != ""
+}
+
+// Token returns the current token of the client, e.g. to persist it.
+func (c *Client) Token() Token {
+	return c.currentToken()
 }
 
 // GetUserPlaylists is targeting the endpoint described here in the spotify web api docs:
 // https://developer.spotify.com/documentation/web-api/reference/#/operations/get-list-users-playlists
-// xxx :  this is not covering the entire functionality yet, e.g. the Pagination or the parsing of all
-// existing response fields. This is just needed for the POC for now.
+// It is only returning the first page, use PaginateUserPlaylists to get all playlists of the user.
 // ref.: https://github.com/HerrGustav/spotify-playlists/issues/1
 func (c *Client) GetUserPlaylists() (UserPlaylists, error) {
+	return c.GetUserPlaylistsContext(context.Background())
+}
+
+// GetUserPlaylistsContext is like GetUserPlaylists, but the request is bound to the given context.
+func (c *Client) GetUserPlaylistsContext(ctx context.Context) (UserPlaylists, error) {
 	if !c.IsAuthorized() {
 		return UserPlaylists{}, newError(notAuthorized, "client is not authorized", nil)
 	}
 
-	req, err := c.createAuthorizedRequest(http.MethodGet, baseURL+"/users/"+c.userName+"/playlists", nil)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	user, err := c.userID(ctx)
+	if err != nil {
+		return UserPlaylists{}, err
+	}
+
+	req, err := c.createAuthorizedRequest(ctx, http.MethodGet, endpoint("users", user, "playlists"), nil)
 	if err != nil {
 		return UserPlaylists{}, err
 	}
@@ -115,6 +182,16 @@ func (c *Client) GetUserPlaylists() (UserPlaylists, error) {
 	return playlists, err
 }
 
+// PaginateUserPlaylists returns a paginator over all playlists of the user, see GetUserPlaylists.
+func (c *Client) PaginateUserPlaylists(ctx context.Context, opts PageOptions) *Paginator {
+	user, err := c.userID(ctx)
+	if err != nil {
+		return &Paginator{c: c, ctx: ctx, err: err}
+	}
+
+	return c.newPaginator(ctx, endpoint("users", user, "playlists"), opts)
+}
+
 type CreatePlaylistPayload struct {
 	Name          string `json:"name"`
 	Public        bool   `json:"public"`
@@ -126,6 +203,11 @@ type CreatePlaylistPayload struct {
 // https://developer.spotify.com/documentation/web-api/reference/#/operations/create-playlist
 // The name of the playlist is the only mandatory input.
 func (c *Client) CreatePlaylist(payload CreatePlaylistPayload) (Playlist, error) {
+	return c.CreatePlaylistContext(context.Background(), payload)
+}
+
+// CreatePlaylistContext is like CreatePlaylist, but the request is bound to the given context.
+func (c *Client) CreatePlaylistContext(ctx context.Context, payload CreatePlaylistPayload) (Playlist, error) {
 	if payload.Name == "" {
 		return Playlist{}, newError(invalidInputs, "playlist name is a required payload field", nil)
 	}
@@ -139,7 +221,15 @@ func (c *Client) CreatePlaylist(payload CreatePlaylistPayload) (Playlist, error)
 		return Playlist{}, newError(internalError, "failed to marshal request payload", err)
 	}
 
-	req, err := c.createAuthorizedRequest(http.MethodPost, baseURL+"/users/"+c.userName+"/playlists", body)
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	user, err := c.userID(ctx)
+	if err != nil {
+		return Playlist{}, err
+	}
+
+	req, err := c.createAuthorizedRequest(ctx, http.MethodPost, endpoint("users", user, "playlists"), body)
 	if err != nil {
 		return Playlist{}, newError(internalError, "failed to create authorized request", err)
 	}
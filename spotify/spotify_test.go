@@ -2,6 +2,7 @@ package spotify
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -56,8 +57,8 @@ func TestAuthorize(t *testing.T) {
 				t.Errorf("spotify.Client.Authorize() got unexpected error, \n got: '%s'", err.Error())
 			}
 
-			if tc.client.token != tc.expectedToken {
-				t.Errorf("spotify.Client.Authorize() mismatch, \n got: '%s', \n want: '%s'", tc.client.token, tc.expectedToken)
+			if got := tc.client.Token().AccessToken; got != tc.expectedToken {
+				t.Errorf("spotify.Client.Authorize() mismatch, \n got: '%s', \n want: '%s'", got, tc.expectedToken)
 			}
 		})
 	}
@@ -78,8 +79,9 @@ func TestGetPlaylists(t *testing.T) {
 				httpClient: &mockHttpClient{
 					expectedError: errMock,
 				},
-				id:     "test",
-				secret: "test",
+				id:       "test",
+				secret:   "test",
+				userName: "user",
 			}),
 		},
 		"successfully retrieved playlists": {
@@ -89,8 +91,9 @@ func TestGetPlaylists(t *testing.T) {
 						Href: "test",
 					}),
 				},
-				id:     "test",
-				secret: "test",
+				id:       "test",
+				secret:   "test",
+				userName: "user",
 			}),
 			want: UserPlaylists{
 				Href: "test",
@@ -142,8 +145,9 @@ func TestCreatePlaylist(t *testing.T) {
 				httpClient: &mockHttpClient{
 					expectedError: errMock,
 				},
-				id:     "test",
-				secret: "test",
+				id:       "test",
+				secret:   "test",
+				userName: "user",
 			}),
 			payload: mockPayload,
 			expectedError: errSpotify{
@@ -157,8 +161,9 @@ func TestCreatePlaylist(t *testing.T) {
 						ID: "1234",
 					}),
 				},
-				id:     "test",
-				secret: "test",
+				id:       "test",
+				secret:   "test",
+				userName: "user",
 			}),
 			payload: mockPayload,
 			expectedOutput: Playlist{
@@ -210,12 +215,43 @@ func createMockedHttpResponse(t *testing.T, status int, v interface{}) *http.Res
 	}
 }
 
+// mockID is a valid id of any kind of object, the ids are validated before a request is sent.
+const mockID = "6rqhFgbbKwnb9MLmUQDhG6"
+
 // mockAuthorizedClient is basically only adding a fake token
 // to the client struct and is imitating the behavior of "client.Authorize()"
 // and the check at "client.IsAuthorized()". This prevents that other unit tests
 // need to deal with this implementation detail.
 func mockAuthorizedClient(baseClient Client) Client {
 	m := baseClient // copy to not return modified inputs.
-	m.token = "12345"
+	m.auth = newTokenSource(Token{AccessToken: "12345"})
 	return m
 }
+
+func TestContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := mockAuthorizedClient(Client{
+		httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			if err := r.Context().Err(); err != nil {
+				return nil, err
+			}
+			return createMockedHttpResponse(t, http.StatusOK, UserPlaylists{}), nil
+		}),
+	})
+
+	_, err := c.GetUserPlaylistsContext(ctx)
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("spotify.Client.GetUserPlaylistsContext() expected a canceled error, got: '%v'", err)
+	}
+
+	_, err = c.CreatePlaylistContext(ctx, CreatePlaylistPayload{Name: "mock"})
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("spotify.Client.CreatePlaylistContext() expected a canceled error, got: '%v'", err)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("spotify.Client.CreatePlaylistContext() expected the context error in the chain, got: '%v'", err)
+	}
+}
@@ -0,0 +1,247 @@
+// Package spotifyuri parses and validates the different ways spotify objects are referenced:
+// URIs like "spotify:track:6rqhFgbbKwnb9MLmUQDhG6", links like
+// "https://open.spotify.com/intl-de/track/6rqhFgbbKwnb9MLmUQDhG6?si=abc" and bare ids.
+// See https://developer.spotify.com/documentation/web-api/concepts/spotify-uris-ids
+package spotifyuri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalid can be used to check via errors.Is if an input could not be parsed.
+var ErrInvalid = errors.New("invalid spotify reference")
+
+// Kind is the type of the object a URI is pointing to.
+type Kind string
+
+const (
+	KindAlbum     Kind = "album"
+	KindArtist    Kind = "artist"
+	KindAudiobook Kind = "audiobook"
+	KindChapter   Kind = "chapter"
+	KindEpisode   Kind = "episode"
+	KindPlaylist  Kind = "playlist"
+	KindShow      Kind = "show"
+	KindTrack     Kind = "track"
+	KindUser      Kind = "user"
+
+	// KindLocal are files of a user that are not available on spotify, e.g. in a playlist.
+	// Their id is the rest of the uri, "spotify:local:<artist>:<album>:<title>:<duration>".
+	KindLocal Kind = "local"
+)
+
+// Valid reports if the kind is known.
+func (k Kind) Valid() bool {
+	switch k {
+	case KindAlbum, KindArtist, KindAudiobook, KindChapter, KindEpisode, KindLocal, KindPlaylist, KindShow, KindTrack, KindUser:
+		return true
+	}
+
+	return false
+}
+
+// ID is the id of an object, a base62 string of 22 characters for every kind but users,
+// whose id is the user name, and local files.
+type ID string
+
+var base62ID = regexp.MustCompile(`^[0-9A-Za-z]{22}$`)
+
+// Validate checks that the id is a valid id of an object of the given kind.
+func (id ID) Validate(k Kind) error {
+	if !k.Valid() {
+		return fmt.Errorf("%w: unknown kind '%s'", ErrInvalid, k)
+	}
+
+	var valid bool
+	switch k {
+	case KindUser:
+		valid = id != "" && !strings.ContainsAny(string(id), ":/")
+	case KindLocal:
+		valid = id != ""
+	default:
+		valid = base62ID.MatchString(string(id))
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: invalid %s id '%s'", ErrInvalid, k, id)
+	}
+
+	return nil
+}
+
+// URI is a reference to an object of spotify, it is canonicalized to the form "spotify:<kind>:<id>".
+type URI struct {
+	Kind Kind
+	ID   ID
+}
+
+// New creates a URI of the given kind and id and validates them.
+func New(k Kind, id ID) (URI, error) {
+	u := URI{Kind: k, ID: id}
+	return u, u.Validate()
+}
+
+// Validate checks the kind and the format of the id.
+func (u URI) Validate() error {
+	return u.ID.Validate(u.Kind)
+}
+
+// IsZero reports if the URI is empty.
+func (u URI) IsZero() bool {
+	return u.Kind == "" && u.ID == ""
+}
+
+// String returns the canonical URI, e.g. "spotify:track:6rqhFgbbKwnb9MLmUQDhG6".
+func (u URI) String() string {
+	if u.IsZero() {
+		return ""
+	}
+
+	return "spotify:" + string(u.Kind) + ":" + string(u.ID)
+}
+
+// URL returns the canonical link to open the object, e.g. "https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6".
+// Local files have no link.
+func (u URI) URL() string {
+	if u.IsZero() || u.Kind == KindLocal {
+		return ""
+	}
+
+	return "https://open.spotify.com/" + string(u.Kind) + "/" + url.PathEscape(string(u.ID))
+}
+
+func (u URI) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *URI) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*u = URI{}
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
+
+// Parse parses a URI or a link of spotify. Bare ids are not accepted,
+// since their kind is unknown, use ParseKind for them.
+func Parse(s string) (URI, error) {
+	s = strings.TrimSpace(s)
+
+	var (
+		u   URI
+		err error
+	)
+	switch {
+	case strings.HasPrefix(s, "spotify:local:"):
+		u = URI{Kind: KindLocal, ID: ID(strings.TrimPrefix(s, "spotify:local:"))}
+	case strings.HasPrefix(s, "spotify:"):
+		u, err = fromSegments(strings.Split(strings.TrimPrefix(s, "spotify:"), ":"))
+	case strings.Contains(s, "spotify.com/"):
+		u, err = parseURL(s)
+	default:
+		return URI{}, fmt.Errorf("%w: '%s' is neither a spotify uri nor a link", ErrInvalid, s)
+	}
+	if err != nil {
+		return URI{}, err
+	}
+
+	return u, u.Validate()
+}
+
+// ParseKind parses a URI, a link or a bare id of an object of the given kind.
+// URIs and links of other kinds are rejected.
+func ParseKind(k Kind, s string) (URI, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.HasPrefix(s, "spotify:") && !strings.Contains(s, "/") {
+		return New(k, ID(s))
+	}
+
+	u, err := Parse(s)
+	if err != nil {
+		return URI{}, err
+	}
+
+	if u.Kind != k {
+		return URI{}, fmt.Errorf("%w: expected a %s, got a %s", ErrInvalid, k, u.Kind)
+	}
+
+	return u, nil
+}
+
+// ParseID is like ParseKind, but returns the id only.
+func ParseID(k Kind, s string) (ID, error) {
+	u, err := ParseKind(k, s)
+	if err != nil {
+		return "", err
+	}
+
+	return u.ID, nil
+}
+
+// intlPrefix is the first path segment of localized share links, e.g. "intl-de" or "intl-pt".
+var intlPrefix = regexp.MustCompile(`^intl-[a-z]{2}(-[a-z]{2})?$`)
+
+func parseURL(s string) (URI, error) {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+
+	parsed, err := url.Parse(s)
+	if err != nil {
+		return URI{}, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+	}
+
+	switch parsed.Hostname() {
+	case "open.spotify.com", "play.spotify.com":
+	default:
+		return URI{}, fmt.Errorf("%w: unknown host '%s'", ErrInvalid, parsed.Hostname())
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) > 0 && (intlPrefix.MatchString(segments[0]) || segments[0] == "embed") {
+		segments = segments[1:]
+	}
+
+	for i, seg := range segments {
+		segments[i], err = url.PathUnescape(seg)
+		if err != nil {
+			return URI{}, fmt.Errorf("%w: %s", ErrInvalid, err.Error())
+		}
+	}
+
+	return fromSegments(segments)
+}
+
+// fromSegments builds a URI from the path of a link or the parts of a URI,
+// e.g. ["track", "<id>"] or the legacy form ["user", "<user>", "playlist", "<id>"].
+func fromSegments(segments []string) (URI, error) {
+	switch {
+	case len(segments) == 2:
+		return URI{Kind: Kind(segments[0]), ID: ID(segments[1])}, nil
+	case len(segments) == 4 && segments[0] == string(KindUser) && segments[2] == string(KindPlaylist):
+		return URI{Kind: KindPlaylist, ID: ID(segments[3])}, nil
+	}
+
+	return URI{}, fmt.Errorf("%w: unexpected format '%s'", ErrInvalid, strings.Join(segments, "/"))
+}
+
+// IDs returns the ids of the URIs.
+func IDs(uris []URI) []ID {
+	ids := make([]ID, len(uris))
+	for i, u := range uris {
+		ids[i] = u.ID
+	}
+	return ids
+}
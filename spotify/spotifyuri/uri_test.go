@@ -0,0 +1,245 @@
+package spotifyuri
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const mockID = "6rqhFgbbKwnb9MLmUQDhG6"
+
+func TestParse(t *testing.T) {
+	testcases := map[string]struct {
+		input       string
+		want        URI
+		shouldError bool
+	}{
+		"uri": {
+			input: "spotify:track:" + mockID,
+			want:  URI{Kind: KindTrack, ID: mockID},
+		},
+		"legacy playlist uri": {
+			input: "spotify:user:someone:playlist:" + mockID,
+			want:  URI{Kind: KindPlaylist, ID: mockID},
+		},
+		"user uri": {
+			input: "spotify:user:some.one",
+			want:  URI{Kind: KindUser, ID: "some.one"},
+		},
+		"link": {
+			input: "https://open.spotify.com/album/" + mockID,
+			want:  URI{Kind: KindAlbum, ID: mockID},
+		},
+		"share link with query and whitespace": {
+			input: " https://open.spotify.com/intl-de/track/" + mockID + "?si=abc123 ",
+			want:  URI{Kind: KindTrack, ID: mockID},
+		},
+		"regional share link": {
+			input: "https://open.spotify.com/intl-pt-br/episode/" + mockID,
+			want:  URI{Kind: KindEpisode, ID: mockID},
+		},
+		"link without scheme": {
+			input: "open.spotify.com/playlist/" + mockID + "/",
+			want:  URI{Kind: KindPlaylist, ID: mockID},
+		},
+		"embed link": {
+			input: "https://open.spotify.com/embed/show/" + mockID,
+			want:  URI{Kind: KindShow, ID: mockID},
+		},
+		"user link with escaped name": {
+			input: "https://open.spotify.com/user/some%20one",
+			want:  URI{Kind: KindUser, ID: "some one"},
+		},
+		"bare id -- should fail": {
+			input:       mockID,
+			shouldError: true,
+		},
+		"local file": {
+			input: "spotify:local:Artist:Album:Some+Title:180",
+			want:  URI{Kind: KindLocal, ID: "Artist:Album:Some+Title:180"},
+		},
+		"unknown kind -- should fail": {
+			input:       "spotify:unknown:" + mockID,
+			shouldError: true,
+		},
+		"invalid id -- should fail": {
+			input:       "spotify:track:1234",
+			shouldError: true,
+		},
+		"foreign host -- should fail": {
+			input:       "https://evil.spotify.com.example/track/" + mockID,
+			shouldError: true,
+		},
+		"missing id -- should fail": {
+			input:       "https://open.spotify.com/track",
+			shouldError: true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if tc.shouldError {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("spotifyuri.Parse() expected an invalid error, got '%v'", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("spotifyuri.Parse() unexpected error '%s'", err.Error())
+			}
+
+			if got != tc.want {
+				t.Errorf("spotifyuri.Parse() mismatch, \n - got: '%+v', \n - want: '%+v'", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	testcases := map[string]struct {
+		kind        Kind
+		input       string
+		shouldError bool
+	}{
+		"bare id": {
+			kind:  KindArtist,
+			input: mockID,
+		},
+		"link of the kind": {
+			kind:  KindArtist,
+			input: "https://open.spotify.com/artist/" + mockID + "?si=1",
+		},
+		"uri of another kind -- should fail": {
+			kind:        KindArtist,
+			input:       "spotify:track:" + mockID,
+			shouldError: true,
+		},
+		"invalid bare id -- should fail": {
+			kind:        KindArtist,
+			input:       "abc",
+			shouldError: true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := ParseID(tc.kind, tc.input)
+			if tc.shouldError {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("spotifyuri.ParseID() expected an invalid error, got '%v'", err)
+				}
+				return
+			}
+
+			if err != nil || got != mockID {
+				t.Errorf("spotifyuri.ParseID() unexpected result '%s', error '%v'", got, err)
+			}
+		})
+	}
+}
+
+func TestIDValidate(t *testing.T) {
+	testcases := map[string]struct {
+		id          ID
+		kind        Kind
+		shouldError bool
+	}{
+		"track id": {
+			id:   mockID,
+			kind: KindTrack,
+		},
+		"user id": {
+			id:   "some.one",
+			kind: KindUser,
+		},
+		"short id -- should fail": {
+			id:          "1234",
+			kind:        KindTrack,
+			shouldError: true,
+		},
+		"uri as id -- should fail": {
+			id:          "spotify:track:" + mockID,
+			kind:        KindTrack,
+			shouldError: true,
+		},
+		"user id with a slash -- should fail": {
+			id:          "a/b",
+			kind:        KindUser,
+			shouldError: true,
+		},
+		"unknown kind -- should fail": {
+			id:          mockID,
+			kind:        "unknown",
+			shouldError: true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			err := tc.id.Validate(tc.kind)
+			if tc.shouldError != (err != nil) {
+				t.Errorf("spotifyuri.ID.Validate() unexpected error '%v'", err)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("spotifyuri.ID.Validate() expected an invalid error, got '%v'", err)
+			}
+		})
+	}
+}
+
+func TestURIFormats(t *testing.T) {
+	u, err := New(KindPlaylist, mockID)
+	if err != nil {
+		t.Fatalf("spotifyuri.New() unexpected error '%s'", err.Error())
+	}
+
+	if got := u.String(); got != "spotify:playlist:"+mockID {
+		t.Errorf("spotifyuri.URI.String() unexpected '%s'", got)
+	}
+
+	if got := u.URL(); got != "https://open.spotify.com/playlist/"+mockID {
+		t.Errorf("spotifyuri.URI.URL() unexpected '%s'", got)
+	}
+
+	if got := (URI{Kind: KindUser, ID: "some one"}).URL(); got != "https://open.spotify.com/user/some%20one" {
+		t.Errorf("spotifyuri.URI.URL() unexpected '%s'", got)
+	}
+
+	// the canonical forms can be parsed again:
+	for _, s := range []string{u.String(), u.URL()} {
+		if got, err := Parse(s); err != nil || got != u {
+			t.Errorf("spotifyuri.Parse('%s') unexpected result '%+v', error '%v'", s, got, err)
+		}
+	}
+}
+
+func TestURIJSON(t *testing.T) {
+	var got struct {
+		URI   URI `json:"uri"`
+		Empty URI `json:"empty"`
+	}
+	err := json.Unmarshal([]byte(`{"uri": "https://open.spotify.com/track/`+mockID+`", "empty": ""}`), &got)
+	if err != nil {
+		t.Fatalf("failed to decode uri, %s", err.Error())
+	}
+
+	if got.URI != (URI{Kind: KindTrack, ID: mockID}) || !got.Empty.IsZero() {
+		t.Errorf("unexpected decoded uris '%+v'", got)
+	}
+
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("failed to encode uri, %s", err.Error())
+	}
+
+	if want := `{"uri":"spotify:track:` + mockID + `","empty":""}`; string(out) != want {
+		t.Errorf("unexpected encoded uris, \n - got: '%s', \n - want: '%s'", out, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"uri": "spotify:track:1"}`), &got); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected an invalid error, got '%v'", err)
+	}
+}
@@ -0,0 +1,212 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// expiryDelta is the time before the actual expiry of a token at which it is already treated as expired.
+// This way a token is refreshed before it expires while a request is on its way.
+const expiryDelta = time.Minute
+
+// timeNow is a variable to be able to fake the current time in tests.
+var timeNow = time.Now
+
+// Token is the representation of the token response of the accounts service described here:
+// https://developer.spotify.com/documentation/general/guides/authorization/code-flow/
+// The relative "expires_in" of the response is converted to an absolute Expiry.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
+}
+
+// Expired reports if the token is expired or about to expire.
+// Tokens without an expiry, e.g. passed in via NewAuthorizedClient, never expire on our side.
+func (t Token) Expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+
+	return timeNow().Add(expiryDelta).After(t.Expiry)
+}
+
+// Valid reports if the token can be used for a request.
+func (t Token) Valid() bool {
+	return t.AccessToken != "" && !t.Expired()
+}
+
+func (a authBody) token() Token {
+	t := Token{
+		AccessToken:  a.AccessToken,
+		TokenType:    a.TokenType,
+		RefreshToken: a.RefreshToken,
+	}
+
+	if a.ExpiresIn > 0 {
+		t.Expiry = timeNow().Add(time.Duration(a.ExpiresIn) * time.Second)
+	}
+
+	if a.Scope != "" {
+		t.Scopes = strings.Fields(a.Scope)
+	}
+
+	return t
+}
+
+// tokenSource holds the token of a client. It is shared between copies of a client,
+// so a refresh is only happening once even if the client is used by multiple goroutines.
+// The token is cached here and every new token is written through to the store.
+type tokenSource struct {
+	mu    sync.Mutex
+	token Token
+	store TokenStore
+	// userID is the id of the user the token belongs to, once it was looked up.
+	// generation is counting the replaced tokens, so a lookup that was running
+	// while the token got replaced is not caching the id of the previous user.
+	userID     string
+	generation int
+}
+
+func newTokenSource(t Token) *tokenSource {
+	return &tokenSource{
+		token: t,
+		store: &MemoryTokenStore{token: t},
+	}
+}
+
+// replaceLocked is like setLocked, but for a new token that may belong to another user,
+// in contrast to a refreshed one. mu has to be held by the caller.
+func (s *tokenSource) replaceLocked(t Token) error {
+	s.forgetUserLocked()
+	return s.setLocked(t)
+}
+
+// forgetUserLocked drops the looked up user, mu has to be held by the caller.
+func (s *tokenSource) forgetUserLocked() {
+	s.userID = ""
+	s.generation++
+}
+
+// setLocked replaces the token and persists it, mu has to be held by the caller.
+func (s *tokenSource) setLocked(t Token) error {
+	s.token = t
+	if s.store == nil {
+		return nil
+	}
+
+	return s.store.Save(t)
+}
+
+func (c *Client) currentToken() Token {
+	if c.auth == nil {
+		return Token{}
+	}
+
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	return c.auth.token
+}
+
+// validToken returns the token of the client and refreshes it beforehand if it is about to expire.
+func (c *Client) validToken(ctx context.Context) (Token, error) {
+	if c.auth == nil {
+		return Token{}, newError(notAuthorized, "client is not authorized", nil)
+	}
+
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if !c.auth.token.Expired() {
+		return c.auth.token, nil
+	}
+
+	return c.refreshLocked(ctx)
+}
+
+// refreshToken is called after the api rejected the given access token.
+// If another goroutine already refreshed the token in the meantime, the new token is returned
+// without requesting another one.
+func (c *Client) refreshToken(ctx context.Context, rejected string) (Token, error) {
+	if c.auth == nil {
+		return Token{}, newError(notAuthorized, "client is not authorized", nil)
+	}
+
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if c.auth.token.AccessToken != rejected && c.auth.token.Valid() {
+		return c.auth.token, nil
+	}
+
+	return c.refreshLocked(ctx)
+}
+
+func (c *Client) canRefresh() bool {
+	t := c.currentToken()
+	return t.RefreshToken != "" || (c.id != "" && c.secret != "")
+}
+
+// refreshLocked requests a new token, c.auth.mu has to be held by the caller.
+// User tokens are refreshed via their refresh token, tokens of the client credentials flow
+// are simply requested again.
+func (c *Client) refreshLocked(ctx context.Context) (Token, error) {
+	current := c.auth.token
+
+	var req *http.Request
+	var err error
+	switch {
+	case current.RefreshToken != "":
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {current.RefreshToken},
+		}
+
+		// apps using PKCE are not able to authenticate via a secret,
+		// so the client id is sent as part of the body instead:
+		authHeader := ""
+		if c.secret != "" {
+			authHeader = createAuthHeader(c.id, c.secret)
+		} else {
+			form.Set("client_id", c.id)
+		}
+
+		req, err = createTokenRequest(ctx, tokenURL(c.accountsBaseURL), form, authHeader)
+	case c.id != "" && c.secret != "":
+		req, err = createAuthRequest(ctx, tokenURL(c.accountsBaseURL), c.id, c.secret)
+	default:
+		return Token{}, newError(notAuthorized, "token expired and can not be refreshed", nil)
+	}
+	if err != nil {
+		return Token{}, newError(internalError, "failed to create refresh request", err)
+	}
+
+	body, err := requestToken(c.httpClient, req)
+	if err != nil {
+		return Token{}, newError(notAuthorized, "failed to refresh token", err)
+	}
+
+	t := body.token()
+	// spotify may not send back a new refresh token, in that case the old one stays valid:
+	if t.RefreshToken == "" {
+		t.RefreshToken = current.RefreshToken
+	}
+	// the scope may be missing as well, in that case the granted scopes are unchanged:
+	if len(t.Scopes) == 0 {
+		t.Scopes = current.Scopes
+	}
+
+	err = c.auth.setLocked(t)
+	if err != nil {
+		return Token{}, newError(internalError, "failed to store refreshed token", err)
+	}
+
+	return t, nil
+}
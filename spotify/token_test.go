@@ -0,0 +1,246 @@
+package spotify
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mockTime(t *testing.T, now time.Time) {
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+}
+
+func mockTokenResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	mockTime(t, now)
+
+	testcases := map[string]struct {
+		token Token
+		want  bool
+	}{
+		"token without expiry never expires": {
+			token: Token{AccessToken: "token"},
+			want:  false,
+		},
+		"token expires in the future": {
+			token: Token{AccessToken: "token", Expiry: now.Add(time.Hour)},
+			want:  false,
+		},
+		"token is about to expire": {
+			token: Token{AccessToken: "token", Expiry: now.Add(expiryDelta / 2)},
+			want:  true,
+		},
+		"token is expired": {
+			token: Token{AccessToken: "token", Expiry: now.Add(-time.Hour)},
+			want:  true,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			if got := tc.token.Expired(); got != tc.want {
+				t.Errorf("Token.Expired() mismatch, \n - got: '%v', \n - want: '%v'", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthBodyToken(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	mockTime(t, now)
+
+	got := authBody{
+		AccessToken:  "access",
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+		RefreshToken: "refresh",
+		Scope:        "playlist-read-private playlist-modify-private",
+	}.token()
+
+	if !got.Expiry.Equal(now.Add(time.Hour)) {
+		t.Errorf("authBody.token() unexpected expiry '%s'", got.Expiry)
+	}
+
+	if len(got.Scopes) != 2 || got.Scopes[1] != "playlist-modify-private" {
+		t.Errorf("authBody.token() unexpected scopes '%v'", got.Scopes)
+	}
+}
+
+func TestRefreshBeforeExpiry(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	mockTime(t, now)
+
+	var tokenRequests int32
+	c := Client{
+		userName: "user",
+		httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.String() == baseTokenURL {
+				atomic.AddInt32(&tokenRequests, 1)
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("refresh request has an invalid form, %s", err.Error())
+				}
+				if r.PostForm.Get("refresh_token") != "refresh" || r.PostForm.Get("client_id") != "id" {
+					t.Errorf("refresh request has unexpected form values '%v'", r.PostForm)
+				}
+				return mockTokenResponse(`{"access_token": "new", "expires_in": 3600}`), nil
+			}
+
+			if r.Header.Get("Authorization") != "Bearer new" {
+				t.Errorf("request was not sent with the refreshed token, got '%s'", r.Header.Get("Authorization"))
+			}
+			return createMockedHttpResponse(t, http.StatusOK, UserPlaylists{Href: "test"}), nil
+		}),
+		id: "id",
+		auth: newTokenSource(Token{
+			AccessToken:  "old",
+			RefreshToken: "refresh",
+			Expiry:       now.Add(time.Second),
+		}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetUserPlaylists(); err != nil {
+				t.Errorf("spotify.Client.GetUserPlaylists() unexpected error '%s'", err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tokenRequests != 1 {
+		t.Errorf("expected exactly one token refresh, got '%d'", tokenRequests)
+	}
+
+	got := c.Token()
+	if got.AccessToken != "new" || got.RefreshToken != "refresh" {
+		t.Errorf("unexpected token after refresh '%+v'", got)
+	}
+}
+
+func TestRefreshKeepsScopes(t *testing.T) {
+	testcases := map[string]struct {
+		response       string
+		expectedScopes []string
+	}{
+		"response without scope": {
+			response:       `{"access_token": "new", "expires_in": 3600}`,
+			expectedScopes: []string{"playlist-read-private", "user-read-private"},
+		},
+		"response with scope": {
+			response:       `{"access_token": "new", "expires_in": 3600, "scope": "user-read-private"}`,
+			expectedScopes: []string{"user-read-private"},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+			mockTime(t, now)
+
+			c := Client{
+				userName: "user",
+				httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+					if r.URL.String() == baseTokenURL {
+						return mockTokenResponse(tc.response), nil
+					}
+					return createMockedHttpResponse(t, http.StatusOK, UserPlaylists{Href: "test"}), nil
+				}),
+				id: "id",
+				auth: newTokenSource(Token{
+					AccessToken:  "old",
+					RefreshToken: "refresh",
+					Expiry:       now.Add(-time.Second),
+					Scopes:       []string{"playlist-read-private", "user-read-private"},
+				}),
+			}
+
+			store := NewMemoryTokenStore()
+			err := c.SetTokenStore(store)
+			if err != nil {
+				t.Fatalf("spotify.Client.SetTokenStore() unexpected error '%s'", err.Error())
+			}
+
+			_, err = c.GetUserPlaylists()
+			if err != nil {
+				t.Fatalf("spotify.Client.GetUserPlaylists() unexpected error '%s'", err.Error())
+			}
+
+			if diff := cmp.Diff(tc.expectedScopes, c.Token().Scopes); diff != "" {
+				t.Errorf("unexpected scopes after refresh (-want +got):\n%s", diff)
+			}
+
+			stored, _ := store.Load()
+			if stored.AccessToken != "new" {
+				t.Fatalf("refreshed token was not saved, got '%+v'", stored)
+			}
+			if diff := cmp.Diff(tc.expectedScopes, stored.Scopes); diff != "" {
+				t.Errorf("unexpected scopes of the stored token (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRefreshAfterUnauthorized(t *testing.T) {
+	testcases := map[string]struct {
+		client        Client
+		expectedError error
+	}{
+		"token can not be refreshed -- return the api error": {
+			client: Client{
+				userName: "user",
+				auth:     newTokenSource(Token{AccessToken: "old"}),
+			},
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+		"client credentials token -- request a new one and replay the request": {
+			client: Client{
+				id:       "id",
+				secret:   "secret",
+				userName: "user",
+				auth:     newTokenSource(Token{AccessToken: "old"}),
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			tc.client.httpClient = mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+				if r.URL.String() == baseTokenURL {
+					return mockTokenResponse(`{"access_token": "new"}`), nil
+				}
+
+				if r.Header.Get("Authorization") != "Bearer new" {
+					return createMockedHttpResponse(t, http.StatusUnauthorized, errResponse{}), nil
+				}
+
+				body, err := io.ReadAll(r.Body)
+				if err != nil || len(body) == 0 {
+					t.Errorf("replayed request has no body")
+				}
+				return createMockedHttpResponse(t, http.StatusCreated, Playlist{ID: "1234"}), nil
+			})
+
+			_, err := tc.client.CreatePlaylist(CreatePlaylistPayload{Name: "mock"})
+			checkSpotifyError(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package spotify
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenStore persists the token of a client. The client is writing every token to the store
+// that it obtains or refreshes, so it can pick up the latest one after a restart, e.g. of a cli
+// or a daemon, without sending the user through the consent screen again.
+type TokenStore interface {
+	// Load returns the stored token or an empty token if nothing was stored yet.
+	Load() (Token, error)
+	// Save replaces the stored token.
+	Save(t Token) error
+}
+
+// MemoryTokenStore keeps the token in memory only. It is the default store of every client.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (m *MemoryTokenStore) Load() (Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.token, nil
+}
+
+func (m *MemoryTokenStore) Save(t Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.token = t
+
+	return nil
+}
+
+// FileTokenStore keeps the token encrypted with AES-GCM in a file, which is only readable
+// by the current user. The key has to be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+	aead cipher.AEAD
+}
+
+func NewFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	if path == "" {
+		return nil, newError(invalidInputs, "path of the token file is required", nil)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, newError(invalidInputs, "invalid encryption key", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, newError(internalError, "failed to create cipher", err)
+	}
+
+	return &FileTokenStore{
+		path: path,
+		aead: aead,
+	}, nil
+}
+
+func (f *FileTokenStore) Load() (Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Token{}, nil
+	}
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to read token file, %w", err)
+	}
+
+	size := f.aead.NonceSize()
+	if len(data) < size {
+		return Token{}, fmt.Errorf("token file is corrupted")
+	}
+
+	plain, err := f.aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to decrypt token file, %w", err)
+	}
+
+	var t Token
+	err = json.Unmarshal(plain, &t)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to decode token, %w", err)
+	}
+
+	return t, nil
+}
+
+func (f *FileTokenStore) Save(t Token) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	plain, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to encode token, %w", err)
+	}
+
+	nonce := make([]byte, f.aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return fmt.Errorf("failed to generate nonce, %w", err)
+	}
+
+	// the nonce is not secret, so it is simply stored in front of the cipher text:
+	data := f.aead.Seal(nonce, nonce, plain, nil)
+
+	// write to a temporary file first and rename it afterwards,
+	// so a crash while writing does not leave a corrupted token file behind.
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create token file, %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write token file, %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write token file, %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), f.path)
+	if err != nil {
+		return fmt.Errorf("failed to replace token file, %w", err)
+	}
+
+	return nil
+}
+
+// SetTokenStore connects the client to the given store. A token that is already stored
+// is used by the client from now on, otherwise the current token of the client is saved.
+// This way a client can be created with NewClient and pick up a previous login:
+//
+//	c := spotify.NewClient(id, "", user)
+//	err := c.SetTokenStore(store)
+//
+// The store is shared with the copies of the client, which is why the client has to be created
+// by one of the constructors.
+func (c *Client) SetTokenStore(store TokenStore) error {
+	if store == nil {
+		return newError(invalidInputs, "token store can not be nil", nil)
+	}
+
+	// the source is shared by the copies of the client, so it can not be created here:
+	if c.auth == nil {
+		return newError(invalidInputs, "client has to be created with New, NewClient or NewAuthorizedClient", nil)
+	}
+
+	stored, err := store.Load()
+	if err != nil {
+		return newError(internalError, "failed to load token from store", err)
+	}
+
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	c.auth.store = store
+	if stored.AccessToken != "" {
+		c.auth.token = stored
+		c.auth.forgetUserLocked()
+		return nil
+	}
+
+	err = store.Save(c.auth.token)
+	if err != nil {
+		return newError(internalError, "failed to save token to store", err)
+	}
+
+	return nil
+}
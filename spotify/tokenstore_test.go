@@ -0,0 +1,213 @@
+package spotify
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var mockKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewFileTokenStore(t *testing.T) {
+	testcases := map[string]struct {
+		path          string
+		key           []byte
+		expectedError error
+	}{
+		"path is missing -- should fail": {
+			key: mockKey,
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"key has an invalid length -- should fail": {
+			path: "token",
+			key:  []byte("short"),
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"valid inputs": {
+			path: "token",
+			key:  mockKey,
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := NewFileTokenStore(tc.path, tc.key)
+			checkSpotifyError(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTokenStores(t *testing.T) {
+	token := Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		Expiry:       time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC),
+		Scopes:       []string{"playlist-read-private"},
+	}
+
+	fileStore, err := NewFileTokenStore(filepath.Join(t.TempDir(), "token"), mockKey)
+	if err != nil {
+		t.Fatalf("NewFileTokenStore() unexpected error '%s'", err.Error())
+	}
+
+	testcases := map[string]TokenStore{
+		"memory store": NewMemoryTokenStore(),
+		"file store":   fileStore,
+	}
+
+	for testName, store := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			got, err := store.Load()
+			if err != nil {
+				t.Fatalf("TokenStore.Load() unexpected error on empty store '%s'", err.Error())
+			}
+
+			if diff := cmp.Diff(Token{}, got); diff != "" {
+				t.Errorf("TokenStore.Load() empty store mismatch (-want +got):\n%s", diff)
+			}
+
+			err = store.Save(token)
+			if err != nil {
+				t.Fatalf("TokenStore.Save() unexpected error '%s'", err.Error())
+			}
+
+			got, err = store.Load()
+			if err != nil {
+				t.Fatalf("TokenStore.Load() unexpected error '%s'", err.Error())
+			}
+
+			if diff := cmp.Diff(token, got); diff != "" {
+				t.Errorf("TokenStore.Load() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileTokenStoreIsEncrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	store, err := NewFileTokenStore(path, mockKey)
+	if err != nil {
+		t.Fatalf("NewFileTokenStore() unexpected error '%s'", err.Error())
+	}
+
+	err = store.Save(Token{AccessToken: "access"})
+	if err != nil {
+		t.Fatalf("FileTokenStore.Save() unexpected error '%s'", err.Error())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read token file '%s'", err.Error())
+	}
+
+	if bytes.Contains(data, []byte("access")) {
+		t.Error("FileTokenStore.Save() stored the token in plain text")
+	}
+
+	other, err := NewFileTokenStore(path, []byte("fedcba9876543210fedcba9876543210"))
+	if err != nil {
+		t.Fatalf("NewFileTokenStore() unexpected error '%s'", err.Error())
+	}
+
+	_, err = other.Load()
+	if err == nil {
+		t.Error("FileTokenStore.Load() was able to decrypt the token with a different key")
+	}
+}
+
+func TestSetTokenStore(t *testing.T) {
+	testcases := map[string]struct {
+		client        Client
+		stored        Token
+		want          Token
+		expectedError error
+	}{
+		"store is empty -- save the current token": {
+			client: NewAuthorizedClient("user", "current"),
+			want:   Token{AccessToken: "current"},
+		},
+		"store has a token -- use the stored token": {
+			client: NewClient("id", "", "user"),
+			stored: Token{AccessToken: "stored", RefreshToken: "refresh"},
+			want:   Token{AccessToken: "stored", RefreshToken: "refresh"},
+		},
+		"client without constructor -- should fail": {
+			client: Client{},
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			store := NewMemoryTokenStore()
+			_ = store.Save(tc.stored)
+
+			err := tc.client.SetTokenStore(store)
+			checkSpotifyError(t, tc.expectedError, err)
+
+			if diff := cmp.Diff(tc.want, tc.client.Token()); diff != "" {
+				t.Errorf("spotify.Client.SetTokenStore() client token mismatch (-want +got):\n%s", diff)
+			}
+
+			stored, _ := store.Load()
+			if diff := cmp.Diff(tc.want, stored); diff != "" {
+				t.Errorf("spotify.Client.SetTokenStore() stored token mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetTokenStoreOfCopy(t *testing.T) {
+	c := NewAuthorizedClient("user", "current")
+	copied := c
+
+	store := NewMemoryTokenStore()
+	_ = store.Save(Token{AccessToken: "stored"})
+
+	err := copied.SetTokenStore(store)
+	if err != nil {
+		t.Fatalf("spotify.Client.SetTokenStore() unexpected error '%s'", err.Error())
+	}
+
+	if got := c.Token().AccessToken; got != "stored" {
+		t.Errorf("spotify.Client.SetTokenStore() token of the original client mismatch, got '%s'", got)
+	}
+}
+
+func TestRefreshSavesToken(t *testing.T) {
+	c := Client{
+		httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			return mockTokenResponse(`{"access_token": "new"}`), nil
+		}),
+		id:     "id",
+		secret: "secret",
+		auth:   newTokenSource(Token{}),
+	}
+
+	store := NewMemoryTokenStore()
+	err := c.SetTokenStore(store)
+	if err != nil {
+		t.Fatalf("spotify.Client.SetTokenStore() unexpected error '%s'", err.Error())
+	}
+
+	err = c.Authorize()
+	if err != nil {
+		t.Fatalf("spotify.Client.Authorize() unexpected error '%s'", err.Error())
+	}
+
+	got, _ := store.Load()
+	if got.AccessToken != "new" {
+		t.Errorf("spotify.Client.Authorize() did not save the token, got '%s'", got.AccessToken)
+	}
+}
@@ -0,0 +1,176 @@
+package spotify
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// ExternalIDs are the known ids of an object outside of spotify.
+type ExternalIDs struct {
+	ISRC string `json:"isrc,omitempty"`
+	EAN  string `json:"ean,omitempty"`
+	UPC  string `json:"upc,omitempty"`
+}
+
+// LinkedTrack is the original track of a relinked track. Spotify is relinking a track
+// if the requested one is not available in the market, but another version of it is.
+type LinkedTrack struct {
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Href         string       `json:"href"`
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
+
+// Restrictions are explaining why content is not playable, e.g. "market", "product" or "explicit".
+type Restrictions struct {
+	Reason string `json:"reason"`
+}
+
+// Track is the full track object described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-track
+type Track struct {
+	Album            SimpleAlbum    `json:"album"`
+	Artists          []SimpleArtist `json:"artists"`
+	AvailableMarkets []string       `json:"available_markets"`
+	DiscNumber       int            `json:"disc_number"`
+	DurationMs       int            `json:"duration_ms"`
+	Explicit         bool           `json:"explicit"`
+	ExternalIDs      ExternalIDs    `json:"external_ids"`
+	ExternalURLs     ExternalURLs   `json:"external_urls"`
+	Href             string         `json:"href"`
+	ID               string         `json:"id"`
+	IsLocal          bool           `json:"is_local"`
+	// IsPlayable, LinkedFrom and Restrictions are only set if a market was requested, see SetMarket.
+	IsPlayable   *bool         `json:"is_playable,omitempty"`
+	LinkedFrom   *LinkedTrack  `json:"linked_from,omitempty"`
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	Name         string        `json:"name"`
+	Popularity   int           `json:"popularity"`
+	PreviewURL   string        `json:"preview_url"`
+	TrackNumber  int           `json:"track_number"`
+	Type         string        `json:"type"`
+	URI          string        `json:"uri"`
+}
+
+// Playable reports if the track can be played in the requested market. Tracks are assumed to be
+// playable if spotify is not telling otherwise, e.g. since no market was requested.
+func (t Track) Playable() bool {
+	return playable(t.IsPlayable, t.Restrictions)
+}
+
+func playable(isPlayable *bool, r *Restrictions) bool {
+	if isPlayable != nil {
+		return *isPlayable
+	}
+
+	return r == nil
+}
+
+// TrackPage is a page of tracks, e.g. of a search.
+type TrackPage struct {
+	Href  string  `json:"href"`
+	Items []Track `json:"items"`
+	Pagination
+}
+
+// SimpleTrack is the simplified track object, as it is included e.g. in albums.
+type SimpleTrack struct {
+	Artists          []SimpleArtist `json:"artists"`
+	AvailableMarkets []string       `json:"available_markets"`
+	DiscNumber       int            `json:"disc_number"`
+	DurationMs       int            `json:"duration_ms"`
+	Explicit         bool           `json:"explicit"`
+	ExternalURLs     ExternalURLs   `json:"external_urls"`
+	Href             string         `json:"href"`
+	ID               string         `json:"id"`
+	IsLocal          bool           `json:"is_local"`
+	// IsPlayable, LinkedFrom and Restrictions are only set if a market was requested, see SetMarket.
+	IsPlayable   *bool         `json:"is_playable,omitempty"`
+	LinkedFrom   *LinkedTrack  `json:"linked_from,omitempty"`
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	Name         string        `json:"name"`
+	PreviewURL   string        `json:"preview_url"`
+	TrackNumber  int           `json:"track_number"`
+	Type         string        `json:"type"`
+	URI          string        `json:"uri"`
+}
+
+// Playable reports if the track can be played in the requested market, see Track.Playable.
+func (t SimpleTrack) Playable() bool {
+	return playable(t.IsPlayable, t.Restrictions)
+}
+
+// SimpleTrackPage is a page of simplified tracks, e.g. of an album.
+type SimpleTrackPage struct {
+	Href  string        `json:"href"`
+	Items []SimpleTrack `json:"items"`
+	Pagination
+}
+
+// maxTracksPerRequest is the maximum number of ids of a single request to the several tracks endpoint.
+const maxTracksPerRequest = 50
+
+// marketQuery returns the query string for the optional market parameter.
+func marketQuery(market string) string {
+	if market == "" {
+		return ""
+	}
+
+	return "?" + url.Values{"market": {market}}.Encode()
+}
+
+// GetTrack returns the track with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-track
+// The market is optional and defaults to the market of the client, see SetMarket.
+func (c *Client) GetTrack(ctx context.Context, id spotifyuri.ID, market string) (Track, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return Track{}, err
+	}
+
+	err = validateID(spotifyuri.KindTrack, id)
+	if err != nil {
+		return Track{}, err
+	}
+
+	var t Track
+	err = c.get(ctx, endpoint("tracks", string(id))+marketQuery(market), &t)
+	if err != nil {
+		return Track{}, err
+	}
+
+	return t, nil
+}
+
+// GetTracks returns the tracks with the given ids as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-several-tracks
+// The tracks are in the same order as the ids, unknown ids result in a nil track.
+// Any number of ids can be passed, they are split into multiple concurrent requests.
+func (c *Client) GetTracks(ctx context.Context, ids []spotifyuri.ID, market string) ([]*Track, error) {
+	market, err := c.marketOr(market)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Track, len(ids))
+	err = batch(ctx, spotifyuri.KindTrack, ids, maxTracksPerRequest, func(ctx context.Context, offset int, chunk []spotifyuri.ID) error {
+		var resp struct {
+			Tracks []*Track `json:"tracks"`
+		}
+		err := c.get(ctx, endpoint("tracks")+idsQuery(chunk, market), &resp)
+		if err != nil {
+			return err
+		}
+
+		copy(out[offset:offset+len(chunk)], resp.Tracks)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
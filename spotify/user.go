@@ -0,0 +1,115 @@
+package spotify
+
+import (
+	"context"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+// ExplicitContent are the explicit content settings of the current user.
+type ExplicitContent struct {
+	FilterEnabled bool `json:"filter_enabled"`
+	FilterLocked  bool `json:"filter_locked"`
+}
+
+// User is the private profile of the current user described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-current-users-profile
+// Country, Email, ExplicitContent and Product are only set if the token has the matching scopes,
+// i.e. user-read-private and user-read-email.
+type User struct {
+	Country         string          `json:"country"`
+	DisplayName     string          `json:"display_name"`
+	Email           string          `json:"email"`
+	ExplicitContent ExplicitContent `json:"explicit_content"`
+	ExternalURLs    ExternalURLs    `json:"external_urls"`
+	Followers       Followers       `json:"followers"`
+	Href            string          `json:"href"`
+	ID              string          `json:"id"`
+	Images          []Image         `json:"images"`
+	Product         string          `json:"product"`
+	Type            string          `json:"type"`
+	URI             string          `json:"uri"`
+}
+
+// PublicUser is the public profile of any user described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-profile
+type PublicUser struct {
+	DisplayName  string       `json:"display_name"`
+	ExternalURLs ExternalURLs `json:"external_urls"`
+	Followers    Followers    `json:"followers"`
+	Href         string       `json:"href"`
+	ID           string       `json:"id"`
+	Images       []Image      `json:"images"`
+	Type         string       `json:"type"`
+	URI          string       `json:"uri"`
+}
+
+// GetCurrentUser returns the profile of the user the token belongs to as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-current-users-profile
+// This is not working with the client credentials flow, since its tokens are not belonging to a user.
+func (c *Client) GetCurrentUser(ctx context.Context) (User, error) {
+	var u User
+	err := c.get(ctx, endpoint("me"), &u)
+	if err != nil {
+		return User{}, err
+	}
+
+	return u, nil
+}
+
+// GetUser returns the public profile of the user with the given id as described here:
+// https://developer.spotify.com/documentation/web-api/reference/#/operations/get-users-profile
+func (c *Client) GetUser(ctx context.Context, id spotifyuri.ID) (PublicUser, error) {
+	err := validateID(spotifyuri.KindUser, id)
+	if err != nil {
+		return PublicUser{}, err
+	}
+
+	var u PublicUser
+	err = c.get(ctx, endpoint("users", string(id)), &u)
+	if err != nil {
+		return PublicUser{}, err
+	}
+
+	return u, nil
+}
+
+// userID returns the configured user name of the client. If there is none, the id of the
+// current user is looked up once per token and shared by all copies of the client.
+// A refreshed token keeps the user, a token of e.g. Authorize or SetTokenStore is looked up again.
+func (c *Client) userID(ctx context.Context) (string, error) {
+	if c.userName != "" {
+		return c.userName, nil
+	}
+
+	generation := 0
+	if c.auth != nil {
+		c.auth.mu.Lock()
+		id := c.auth.userID
+		generation = c.auth.generation
+		c.auth.mu.Unlock()
+
+		if id != "" {
+			return id, nil
+		}
+	}
+
+	u, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if u.ID == "" {
+		return "", newError(internalError, "current user has no id", nil)
+	}
+
+	if c.auth != nil {
+		c.auth.mu.Lock()
+		if c.auth.generation == generation {
+			c.auth.userID = u.ID
+		}
+		c.auth.mu.Unlock()
+	}
+
+	return u.ID, nil
+}
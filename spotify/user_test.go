@@ -0,0 +1,177 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HerrGustav/spotify-playlists/spotify/spotifyuri"
+)
+
+func TestGetUser(t *testing.T) {
+	testcases := map[string]struct {
+		id            spotifyuri.ID
+		expectedPath  string
+		expectedError error
+	}{
+		"no id -- should fail": {
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+		"plain id": {
+			id:           "user",
+			expectedPath: "/v1/users/user",
+		},
+		"special characters are escaped": {
+			id:           "a b?#",
+			expectedPath: "/v1/users/a%20b%3F%23",
+		},
+		"id with a slash -- should fail": {
+			id: "a/b",
+			expectedError: errSpotify{
+				code: invalidInputs,
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			httpClient := &mockHttpClient{expectedResponse: createMockedHttpResponse(t, http.StatusOK, PublicUser{ID: string(tc.id)})}
+			c := mockAuthorizedClient(Client{httpClient: httpClient})
+
+			got, err := c.GetUser(context.Background(), tc.id)
+			checkSpotifyError(t, tc.expectedError, err)
+			if err != nil {
+				return
+			}
+
+			if p := httpClient.gotRequest.URL.EscapedPath(); p != tc.expectedPath {
+				t.Errorf("unexpected path, \n - got: '%s', \n - want: '%s'", p, tc.expectedPath)
+			}
+
+			if got.ID != string(tc.id) {
+				t.Errorf("spotify.Client.GetUser() unexpected id '%s'", got.ID)
+			}
+		})
+	}
+}
+
+func TestUserID(t *testing.T) {
+	testcases := map[string]struct {
+		userName      string
+		meResponse    *http.Response
+		expected      string
+		expectedCalls int
+		expectedError error
+	}{
+		"configured user name -- no lookup": {
+			userName: "configured",
+			expected: "configured",
+		},
+		"no user name -- look up the current user once": {
+			meResponse:    createMockedHttpResponse(t, http.StatusOK, User{ID: "me"}),
+			expected:      "me",
+			expectedCalls: 1,
+		},
+		"lookup failed -- return the error": {
+			meResponse:    createMockedHttpResponse(t, http.StatusForbidden, nil),
+			expectedCalls: 1,
+			expectedError: errSpotify{
+				code: requestFailed,
+			},
+		},
+	}
+
+	for testName, tc := range testcases {
+		t.Run(testName, func(t *testing.T) {
+			calls := 0
+			c := mockAuthorizedClient(Client{
+				userName: tc.userName,
+				retry:    RetryPolicy{MaxAttempts: 1},
+				httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+					calls++
+					if r.URL.Path != "/v1/me" {
+						t.Errorf("unexpected path '%s'", r.URL.Path)
+					}
+					return tc.meResponse, nil
+				}),
+			})
+
+			// a copy of the client shares the looked up user:
+			cp := c
+			for _, client := range []*Client{&c, &cp} {
+				got, err := client.userID(context.Background())
+				checkSpotifyError(t, tc.expectedError, err)
+
+				if got != tc.expected {
+					t.Errorf("spotify.Client.userID() mismatch, \n - got: '%s', \n - want: '%s'", got, tc.expected)
+				}
+
+				if err != nil {
+					break
+				}
+			}
+
+			if calls != tc.expectedCalls {
+				t.Errorf("spotify.Client.userID() expected '%d' lookups, got '%d'", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestUserIDAfterTokenChange(t *testing.T) {
+	lookups := 0
+	c := mockAuthorizedClient(Client{
+		id:     "id",
+		secret: "secret",
+		httpClient: mockHttpClientFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.String() == baseTokenURL {
+				return mockTokenResponse(`{"access_token": "authorized"}`), nil
+			}
+
+			// the token is the user of the mock:
+			lookups++
+			return createMockedHttpResponse(t, http.StatusOK, User{ID: strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")}), nil
+		}),
+	})
+
+	steps := []struct {
+		name        string
+		change      func() error
+		want        string
+		wantLookups int
+	}{
+		{name: "first lookup", want: "12345", wantLookups: 1},
+		{name: "cached", want: "12345", wantLookups: 1},
+		{
+			name: "token of the store",
+			change: func() error {
+				store := NewMemoryTokenStore()
+				_ = store.Save(Token{AccessToken: "stored"})
+				return c.SetTokenStore(store)
+			},
+			want:        "stored",
+			wantLookups: 2,
+		},
+		{name: "authorized again", change: c.Authorize, want: "authorized", wantLookups: 3},
+	}
+
+	for _, step := range steps {
+		if step.change != nil {
+			if err := step.change(); err != nil {
+				t.Fatalf("%s: unexpected error '%s'", step.name, err.Error())
+			}
+		}
+
+		got, err := c.userID(context.Background())
+		if err != nil {
+			t.Fatalf("%s: spotify.Client.userID() unexpected error '%s'", step.name, err.Error())
+		}
+
+		if got != step.want || lookups != step.wantLookups {
+			t.Errorf("%s: spotify.Client.userID() got '%s' after '%d' lookups, want '%s' after '%d'", step.name, got, lookups, step.want, step.wantLookups)
+		}
+	}
+}